@@ -0,0 +1,183 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v4"
+	"math"
+	"time"
+)
+
+// getGuildCountHistory returns every posted guild count since the given time, oldest first. The last count posted
+// before that time is included as well so that changes can be measured from the start of the range.
+func getGuildCountHistory(since time.Time) ([]GuildCountPoint, error) {
+	var points []GuildCountPoint
+
+	var baseline GuildCountPoint
+	var baselineAt time.Time
+	baselineQuery := `select guild_count, created_at from guildcount
+		where shard_count is not null and created_at < $1 order by created_at desc limit 1`
+	err := conn.QueryRow(context.Background(), baselineQuery, since.UTC()).Scan(&baseline.Guilds, &baselineAt)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	if err == nil {
+		baseline.Time = baselineAt
+		points = append(points, baseline)
+	}
+
+	query := "select guild_count, created_at from guildcount where shard_count is not null and created_at >= $1 order by created_at"
+	rows, rowsErr := queryRows(query, since.UTC())
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var point GuildCountPoint
+		if scanErr := rows.Scan(&point.Guilds, &point.Time); scanErr != nil {
+			return nil, scanErr
+		}
+
+		points = append(points, point)
+	}
+
+	return points, rows.Err()
+}
+
+// guildCountAt returns the last guild count posted at or before the time, falling back to the earliest count known.
+func guildCountAt(points []GuildCountPoint, at time.Time) int64 {
+	count := points[0].Guilds
+	for _, point := range points {
+		if point.Time.After(at) {
+			break
+		}
+
+		count = point.Guilds
+	}
+
+	return count
+}
+
+func calculateGrowthWindow(points []GuildCountPoint, name string, window time.Duration, now time.Time) GrowthWindow {
+	growth := GrowthWindow{Window: name}
+	if len(points) == 0 {
+		return growth
+	}
+
+	growth.Start = guildCountAt(points, now.Add(-window))
+	growth.End = points[len(points)-1].Guilds
+	growth.NetChange = growth.End - growth.Start
+	if growth.Start > 0 {
+		growth.GrowthRate = float64(growth.NetChange) / float64(growth.Start) * 100
+	}
+
+	return growth
+}
+
+// calculateDailyGrowth estimates the guilds joined and left on each UTC day from the changes between consecutive
+// posts, along with each day's closing count and its moving average over the configured amount of days. Guilds that
+// join and leave between two posts cancel out, so joins and leaves are lower bounds.
+func calculateDailyGrowth(points []GuildCountPoint, days int, averageDays int, now time.Time) []DailyGrowth {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	first := today.AddDate(0, 0, -(days - 1))
+
+	daily := make([]DailyGrowth, days)
+	for i := range daily {
+		daily[i].Date = first.AddDate(0, 0, i).Format("2006-01-02")
+	}
+
+	for i := 1; i < len(points); i++ {
+		at := points[i].Time.UTC()
+		if at.Before(first) {
+			continue
+		}
+
+		index := int(at.Sub(first).Hours() / 24)
+		if index >= days {
+			continue
+		}
+
+		delta := points[i].Guilds - points[i-1].Guilds
+		if delta > 0 {
+			daily[index].Joins += delta
+		} else {
+			daily[index].Leaves -= delta
+		}
+
+		daily[index].NetChange += delta
+	}
+
+	var sum float64
+	var counted []int64
+	for i := range daily {
+		end := first.AddDate(0, 0, i+1).Add(-time.Nanosecond)
+		if len(points) > 0 && !points[0].Time.After(end) {
+			daily[i].Close = guildCountAt(points, end)
+			counted = append(counted, daily[i].Close)
+			sum += float64(daily[i].Close)
+		}
+
+		if len(counted) > averageDays {
+			sum -= float64(counted[len(counted)-averageDays-1])
+		}
+
+		if n := len(counted); n > 0 {
+			daily[i].MovingAverage = sum / math.Min(float64(n), float64(averageDays))
+		}
+	}
+
+	return daily
+}
+
+// maxProjectionDays is how far ahead the next milestone is projected. Growth slow enough to take longer than this isn't
+// a meaningful estimate, and would overflow the duration it's added as.
+const maxProjectionDays = 3650
+
+// calculateProjection fits a least squares line through the guild counts to estimate the daily growth, then projects
+// when the next milestone will be reached at that rate, unless that's further ahead than the max projection.
+func calculateProjection(points []GuildCountPoint, now time.Time) GrowthProjection {
+	var projection GrowthProjection
+	if len(points) == 0 {
+		return projection
+	}
+
+	latest := points[len(points)-1].Guilds
+	projection.NextMilestone = getNextMilestone(latest)
+
+	if len(points) < 2 {
+		return projection
+	}
+
+	origin := points[0].Time
+	var sumX, sumY, sumXY, sumXX float64
+	for _, point := range points {
+		x := point.Time.Sub(origin).Hours() / 24
+		y := float64(point.Guilds)
+
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	n := float64(len(points))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return projection
+	}
+
+	projection.DailyGrowth = (n*sumXY - sumX*sumY) / denominator
+
+	if projection.NextMilestone > 0 && projection.DailyGrowth > 0 {
+		days := float64(projection.NextMilestone-latest) / projection.DailyGrowth
+		if days <= maxProjectionDays {
+			projection.EstimatedAt = now.Add(time.Duration(days * float64(time.Hour*24))).UnixMilli()
+		}
+	}
+
+	return projection
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+	"log"
+	"strings"
+	"time"
+)
+
+// sensitiveFields are redacted from request bodies before they are stored in the audit log. A field is sensitive when
+// its name contains any of them.
+var sensitiveFields = []string{"secret", "token", "password"}
+
+// auditMiddleware records every mutating request made with an API key after it has been handled, along with the
+// name of the key it was made with and its result. Entries are stored without holding up the response.
+func auditMiddleware(ctx *fiber.Ctx) error {
+	if ctx.Method() == fiber.MethodGet || ctx.Method() == fiber.MethodHead || ctx.Method() == fiber.MethodOptions {
+		return ctx.Next()
+	}
+
+	err := ctx.Next()
+
+	// The entry outlives the request, so the strings Fiber reuses between requests are copied.
+	entry := AuditEntry{
+		KeyName:    getApiKeyName(ctx),
+		Ip:         utils.CopyString(ctx.IP()),
+		Method:     utils.CopyString(ctx.Method()),
+		Route:      ctx.Route().Path,
+		Path:       utils.CopyString(ctx.Path()),
+		Body:       summarizeBody(ctx.Body()),
+		StatusCode: ctx.Response().StatusCode(),
+	}
+
+	if err != nil {
+		entry.StatusCode = fiber.StatusInternalServerError
+		entry.Error = err.Error()
+
+		var fiberErr *fiber.Error
+		if errors.As(err, &fiberErr) {
+			entry.StatusCode = fiberErr.Code
+			entry.Error = fiberErr.Message
+		}
+
+		entry.Error = redactSecrets(entry.Error)
+	}
+
+	go func() {
+		if insertErr := insertAuditEntry(entry); insertErr != nil {
+			log.Printf("Failed to record the %s %s request in the audit log: %s", entry.Method, entry.Path, insertErr)
+		}
+	}()
+
+	return err
+}
+
+// summarizeBody returns the request body with sensitive fields and known secrets redacted, cut off at the configured
+// limit. Bodies that aren't JSON are only summarized by their size, as they can't be redacted.
+func summarizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return fmt.Sprintf("<%d bytes>", len(body))
+	}
+
+	encoded, _ := json.Marshal(redactFields(data))
+	summary := redactSecrets(string(encoded))
+
+	limit := int(config.GetDefault("audit.body_limit", int64(2048)).(int64))
+	if len(summary) > limit {
+		return summary[:limit] + "..."
+	}
+
+	return summary
+}
+
+func redactFields(data interface{}) interface{} {
+	switch value := data.(type) {
+	case map[string]interface{}:
+		for key, field := range value {
+			if isSensitiveField(key) {
+				value[key] = redactedValue
+				continue
+			}
+
+			value[key] = redactFields(field)
+		}
+	case []interface{}:
+		for i, item := range value {
+			value[i] = redactFields(item)
+		}
+	}
+
+	return data
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, sensitive := range sensitiveFields {
+		if strings.Contains(name, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func insertAuditEntry(entry AuditEntry) error {
+	query := `insert into audit_log(key_name, ip, method, route, path, body, status_code, error)
+		values ($1, $2, $3, $4, $5, nullif($6, ''), $7, nullif($8, ''))`
+	_, err := execQuery(
+		query, entry.KeyName, entry.Ip, entry.Method, entry.Route, entry.Path, entry.Body, entry.StatusCode, entry.Error,
+	)
+
+	return err
+}
+
+// getAuditEntries returns a page of audit log entries matching the filter, most recent first, along with the total
+// amount of matches.
+func getAuditEntries(filter AuditQuery) ([]AuditEntry, int64, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Key != "" {
+		addCondition("key_name = $%d", filter.Key)
+	}
+
+	if filter.Method != "" {
+		addCondition("method = $%d", strings.ToUpper(filter.Method))
+	}
+
+	if filter.Route != "" {
+		addCondition("route = $%d", filter.Route)
+	}
+
+	if filter.Status > 0 {
+		addCondition("status_code = $%d", filter.Status)
+	}
+
+	if filter.From > 0 {
+		addCondition("created_at >= $%d", time.UnixMilli(filter.From).UTC())
+	}
+
+	if filter.To > 0 {
+		addCondition("created_at < $%d", time.UnixMilli(filter.To).UTC())
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " where " + strings.Join(conditions, " and ")
+	}
+
+	var total int64
+	countErr := conn.QueryRow(context.Background(), "select count(*) from audit_log"+where, args...).Scan(&total)
+	if countErr != nil {
+		return nil, 0, countErr
+	}
+
+	query := fmt.Sprintf(
+		`select id, coalesce(key_name, ''), coalesce(ip, ''), method, route, path, coalesce(body, ''), status_code,
+			coalesce(error, ''), created_at
+		from audit_log%s order by created_at desc, id desc limit $%d offset $%d`,
+		where, len(args)+1, len(args)+2,
+	)
+	rows, err := queryRows(query, append(args, filter.Limit, (filter.Page-1)*filter.Limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	entries := make([]AuditEntry, 0)
+	for rows.Next() {
+		var entry AuditEntry
+		var createdAt time.Time
+		scanErr := rows.Scan(
+			&entry.Id, &entry.KeyName, &entry.Ip, &entry.Method, &entry.Route, &entry.Path, &entry.Body,
+			&entry.StatusCode, &entry.Error, &createdAt,
+		)
+		if scanErr != nil {
+			return nil, 0, scanErr
+		}
+
+		entry.Timestamp = createdAt.UnixMilli()
+		entries = append(entries, entry)
+	}
+
+	return entries, total, rows.Err()
+}
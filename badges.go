@@ -0,0 +1,168 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"html"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var hexColorPattern = regexp.MustCompile(`^[0-9a-fA-F]{3}([0-9a-fA-F]{3})?$`)
+
+var badgeColors = map[string]string{
+	"brightgreen": "#4c1",
+	"green":       "#97ca00",
+	"yellow":      "#dfb317",
+	"orange":      "#fe7d37",
+	"red":         "#e05d44",
+	"blue":        "#007ec6",
+	"grey":        "#555",
+	"blurple":     "#5865f2",
+}
+
+// resolveBadgeColor accepts either a named color or a hex color without the leading #, falling back to the given
+// color for anything else so that arbitrary input never ends up in the SVG.
+func resolveBadgeColor(color string, fallback string) string {
+	if named, ok := badgeColors[strings.ToLower(color)]; ok {
+		return named
+	}
+
+	if hexColorPattern.MatchString(color) {
+		return "#" + color
+	}
+
+	return fallback
+}
+
+// formatBadgeNumber formats the number either in full with thousands separators, or compacted to thousands and
+// millions like 52k and 1.2M.
+func formatBadgeNumber(value int64, format string) string {
+	if format == "compact" {
+		abs := math.Abs(float64(value))
+		switch {
+		case abs >= 1_000_000:
+			return strings.TrimSuffix(strconv.FormatFloat(float64(value)/1_000_000, 'f', 1, 64), ".0") + "M"
+		case abs >= 1_000:
+			return strings.TrimSuffix(strconv.FormatFloat(float64(value)/1_000, 'f', 1, 64), ".0") + "k"
+		}
+	}
+
+	digits := strconv.FormatInt(value, 10)
+	sign := ""
+	if strings.HasPrefix(digits, "-") {
+		sign, digits = "-", digits[1:]
+	}
+
+	var groups []string
+	for len(digits) > 3 {
+		groups = append([]string{digits[len(digits)-3:]}, groups...)
+		digits = digits[:len(digits)-3]
+	}
+
+	return sign + strings.Join(append([]string{digits}, groups...), ",")
+}
+
+// estimateTextWidth approximates the rendered width of text in 11px Verdana, which is what badges are rendered with.
+func estimateTextWidth(text string) int {
+	width := 0.0
+	for _, r := range text {
+		switch {
+		case strings.ContainsRune("il.,:;|!'", r):
+			width += 3.5
+		case strings.ContainsRune("mwMW", r):
+			width += 10
+		case r >= 'A' && r <= 'Z':
+			width += 8
+		default:
+			width += 7
+		}
+	}
+
+	return int(math.Ceil(width))
+}
+
+// renderBadge renders a flat shields-style badge with the label on the left and the value on the right.
+func renderBadge(label string, value string, color string) string {
+	labelWidth := estimateTextWidth(label) + 10
+	valueWidth := estimateTextWidth(value) + 10
+	width := labelWidth + valueWidth
+
+	label = html.EscapeString(label)
+	value = html.EscapeString(value)
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">`+
+		`<title>%[4]s: %[5]s</title>`+
+		`<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`+
+		`<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>`+
+		`<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>`+
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`+
+		`<text x="%[7]d" y="15" fill="#010101" fill-opacity=".3">%[4]s</text><text x="%[7]d" y="14">%[4]s</text>`+
+		`<text x="%[8]d" y="15" fill="#010101" fill-opacity=".3">%[5]s</text><text x="%[8]d" y="14">%[5]s</text>`+
+		`</g></svg>`,
+		width, labelWidth, valueWidth, label, value, color, labelWidth/2, labelWidth+valueWidth/2,
+	)
+}
+
+// renderSparkline renders the values as a line scaled to fill the given size, with the lowest value at the bottom
+// and the highest at the top.
+func renderSparkline(values []int64, width int, height int, color string) string {
+	const padding = 2
+
+	var points []string
+	if len(values) > 0 {
+		lowest, highest := values[0], values[0]
+		for _, value := range values {
+			if value < lowest {
+				lowest = value
+			}
+
+			if value > highest {
+				highest = value
+			}
+		}
+
+		for i, value := range values {
+			x := float64(width) / 2
+			if len(values) > 1 {
+				x = padding + float64(i)*float64(width-padding*2)/float64(len(values)-1)
+			}
+
+			y := float64(height) / 2
+			if highest > lowest {
+				y = padding + float64(highest-value)*float64(height-padding*2)/float64(highest-lowest)
+			}
+
+			points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+		}
+	}
+
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="%[2]d" viewBox="0 0 %[1]d %[2]d" role="img" aria-label="Guild count growth">`+
+		`<title>Guild count growth</title>`+
+		`<polyline fill="none" stroke="%[3]s" stroke-width="1.5" stroke-linejoin="round" stroke-linecap="round" points="%[4]s"/>`+
+		`</svg>`,
+		width, height, color, strings.Join(points, " "),
+	)
+}
+
+// sendSvg responds with the SVG, allowing it to be cached for the configured badge TTL. SVGs rendered for unavailable
+// data aren't cached so that the next request can try again.
+func sendSvg(ctx *fiber.Ctx, svg string, cache bool) error {
+	ctx.Set(fiber.HeaderContentType, "image/svg+xml; charset=utf-8")
+
+	if cache {
+		maxAge := getConfigDuration("public.badges.cache_ttl", time.Minute*5)
+		ctx.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	} else {
+		ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	}
+
+	return ctx.SendString(svg)
+}
+
+func getDefaultBadgeColor() string {
+	return resolveBadgeColor(config.GetDefault("public.badges.color", "blurple").(string), badgeColors["blurple"])
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half_open"
+)
+
+// circuitBreaker stops requests to a service after it failed too many times in a row, so that a list being down
+// doesn't make every request wait for it to time out. Once the cooldown has passed a single probe request is let
+// through, closing the breaker again if it succeeds.
+type circuitBreaker struct {
+	mutex    sync.Mutex
+	service  string
+	state    string
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+var breakers = struct {
+	mutex    sync.Mutex
+	services map[string]*circuitBreaker
+}{services: make(map[string]*circuitBreaker)}
+
+func getCircuitBreaker(service string) *circuitBreaker {
+	breakers.mutex.Lock()
+	defer breakers.mutex.Unlock()
+
+	breaker, ok := breakers.services[service]
+	if !ok {
+		breaker = &circuitBreaker{service: service, state: breakerClosed}
+		breakers.services[service] = breaker
+	}
+
+	return breaker
+}
+
+func getBreakerCooldown() time.Duration {
+	return getConfigDuration("breaker.cooldown", time.Minute)
+}
+
+// allow reports whether a request may be sent, moving an open breaker to half open once its cooldown has passed.
+func (b *circuitBreaker) allow() bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < getBreakerCooldown() {
+			return false
+		}
+
+		b.state = breakerHalfOpen
+		b.probing = true
+		return true
+	case breakerHalfOpen:
+		if b.probing {
+			return false
+		}
+
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+// record tracks the outcome of a request let through by allow.
+func (b *circuitBreaker) record(success bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.probing = false
+
+	if success {
+		if b.state != breakerClosed {
+			log.Printf("The circuit breaker for %s has closed.", b.service)
+		}
+
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+
+	threshold := int(config.GetDefault("breaker.failure_threshold", int64(5)).(int64))
+	if b.state == breakerHalfOpen || b.failures >= threshold {
+		if b.state != breakerOpen {
+			log.Printf("The circuit breaker for %s has opened after %d failures.", b.service, b.failures)
+		}
+
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *circuitBreaker) status() BreakerStatus {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	status := BreakerStatus{State: b.state, Failures: b.failures}
+	if b.state != breakerClosed {
+		status.OpenedAt = b.openedAt.UnixMilli()
+		status.RetryAt = b.openedAt.Add(getBreakerCooldown()).UnixMilli()
+	}
+
+	return status
+}
+
+// doWithBreaker sends the request through the service's circuit breaker. Network errors and server errors count as
+// failures, any other response means the service is up.
+func doWithBreaker(httpClient *http.Client, service string, req *http.Request) (*http.Response, error) {
+	breaker := getCircuitBreaker(service)
+	if !breaker.allow() {
+		status := breaker.status()
+		return nil, fmt.Errorf("The circuit breaker is open, requests are skipped until %s.", time.UnixMilli(status.RetryAt).UTC().Format(time.RFC3339))
+	}
+
+	resp, err := httpClient.Do(req)
+	breaker.record(err == nil && resp.StatusCode < 500)
+
+	return resp, err
+}
+
+// withBreakerStatus returns a copy of the responses with the current state of each service's circuit breaker.
+func withBreakerStatus(responses []BotListServiceResponse) []BotListServiceResponse {
+	services := make([]BotListServiceResponse, len(responses))
+	for i, response := range responses {
+		status := getCircuitBreaker(response.ShortName).status()
+		response.Breaker = &status
+		services[i] = response
+	}
+
+	return services
+}
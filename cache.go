@@ -0,0 +1,141 @@
+package main
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+// serviceDataCache holds the most recent successful fetch of all active bot lists so that reads don't have to fan out
+// to every list. Data older than the TTL is still served while it is within the stale TTL, and is refreshed in the
+// background in the meantime. Only one refresh runs at a time, which every caller needing fresh data waits on.
+type serviceDataCache struct {
+	mutex     sync.Mutex
+	responses []BotListServiceResponse
+	fetchedAt time.Time
+	inflight  *serviceDataRefresh
+}
+
+// serviceDataRefresh is a fetch from all active bot lists that is in progress, shared by everyone waiting on it.
+type serviceDataRefresh struct {
+	done      chan struct{}
+	responses []BotListServiceResponse
+	errors    []error
+}
+
+var servicesCache = &serviceDataCache{}
+
+// get returns the cached service data along with its age, fetching it live when the cache is empty, expired past its
+// stale TTL or when fresh data is explicitly requested. Expired data is still served while a refresh is running.
+func (c *serviceDataCache) get(fresh bool) ([]BotListServiceResponse, time.Duration, []error) {
+	ttl := getConfigDuration("api.cache.ttl", time.Minute)
+	staleTtl := getConfigDuration("api.cache.stale_ttl", time.Minute*10)
+
+	c.mutex.Lock()
+	if !fresh && !c.fetchedAt.IsZero() {
+		age := time.Since(c.fetchedAt)
+		if age < ttl+staleTtl || c.inflight != nil {
+			if age >= ttl && c.inflight == nil {
+				go c.revalidate()
+			}
+
+			responses := append([]BotListServiceResponse(nil), c.responses...)
+			c.mutex.Unlock()
+
+			return responses, age, nil
+		}
+	}
+	c.mutex.Unlock()
+
+	responses, errors := c.refresh()
+
+	return responses, 0, errors
+}
+
+// refresh fetches the data from all active bot lists and stores it, unless any of the lists failed. When a refresh is
+// already running, its result is waited on instead of fetching the data again.
+func (c *serviceDataCache) refresh() ([]BotListServiceResponse, []error) {
+	c.mutex.Lock()
+	if call := c.inflight; call != nil {
+		c.mutex.Unlock()
+		<-call.done
+
+		return append([]BotListServiceResponse(nil), call.responses...), call.errors
+	}
+
+	call := &serviceDataRefresh{done: make(chan struct{})}
+	c.inflight = call
+	c.mutex.Unlock()
+
+	call.responses, call.errors = fetchBotListServiceData()
+
+	c.mutex.Lock()
+	c.inflight = nil
+	if len(call.errors) == 0 {
+		c.responses = call.responses
+		c.fetchedAt = time.Now()
+
+		go checkDrift(call.responses)
+	}
+	c.mutex.Unlock()
+
+	close(call.done)
+
+	return append([]BotListServiceResponse(nil), call.responses...), call.errors
+}
+
+func (c *serviceDataCache) revalidate() {
+	_, errors := c.refresh()
+	for _, err := range errors {
+		log.Printf("Failed to refresh the service data cache: %s", err)
+	}
+}
+
+// startServiceDataRefresher keeps the cache warm by refreshing it on the configured interval. It does nothing when
+// no interval is configured.
+func startServiceDataRefresher() {
+	interval := getConfigDuration("api.cache.refresh_interval", 0)
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		for range time.Tick(interval) {
+			servicesCache.revalidate()
+		}
+	}()
+}
+
+// publicGuildCountCache holds the latest guild count served on the public routes, so that they don't query the
+// database on every request.
+type publicGuildCountCache struct {
+	mutex     sync.Mutex
+	response  PublicGuildCountResponse
+	fetchedAt time.Time
+}
+
+var publicGuildCount = &publicGuildCountCache{}
+
+func (c *publicGuildCountCache) get() (PublicGuildCountResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.fetchedAt.IsZero() && time.Since(c.fetchedAt) < getConfigDuration("public.cache_ttl", time.Minute) {
+		return c.response, nil
+	}
+
+	var guildCount int64
+	var createdAt time.Time
+	query := "select guild_count, created_at from guildcount where shard_count is not null order by created_at desc"
+	if err := queryRow(query, &guildCount, &createdAt); err != nil {
+		return PublicGuildCountResponse{}, err
+	}
+
+	c.response = PublicGuildCountResponse{
+		GuildCount: guildCount,
+		Timestamp:  createdAt.UnixMilli(),
+	}
+	c.fetchedAt = time.Now()
+
+	return c.response, nil
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// serviceClients holds an HTTP client per service, built from the config the first time the service is requested.
+// Services without proxy or TLS overrides share one transport so that connections are reused across them.
+var serviceClients = struct {
+	mutex     sync.Mutex
+	transport *http.Transport
+	clients   map[string]*http.Client
+}{clients: make(map[string]*http.Client)}
+
+// serviceTransport sets the User-Agent on every request and caps how many requests to the service are in flight at
+// once. A request holds its slot until its body is closed.
+type serviceTransport struct {
+	base      http.RoundTripper
+	userAgent string
+	slots     chan struct{}
+}
+
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
+
+func (t *serviceTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.userAgent != "" && req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	if t.slots == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	select {
+	case t.slots <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+
+	release := func() { <-t.slots }
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: release}
+
+	return resp, nil
+}
+
+func newTransport() *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   getConfigDuration("http.dial_timeout", time.Second*10),
+			KeepAlive: time.Second * 30,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          int(config.GetDefault("http.max_idle_conns", int64(100)).(int64)),
+		MaxIdleConnsPerHost:   int(config.GetDefault("http.max_idle_conns_per_host", int64(10)).(int64)),
+		IdleConnTimeout:       getConfigDuration("http.idle_conn_timeout", time.Second*90),
+		TLSHandshakeTimeout:   getConfigDuration("http.tls_handshake_timeout", time.Second*10),
+		ExpectContinueTimeout: time.Second,
+	}
+}
+
+// getSharedTransport returns the transport used by services without proxy or TLS overrides. The caller must hold the
+// serviceClients mutex.
+func getSharedTransport() *http.Transport {
+	if serviceClients.transport == nil {
+		serviceClients.transport = newTransport()
+	}
+
+	return serviceClients.transport
+}
+
+// getServiceHttpKey returns the config key of the service's HTTP override, falling back to the global HTTP config when
+// the service doesn't override it.
+func getServiceHttpKey(service string, key string) string {
+	serviceKey := fmt.Sprintf("services.%s.http.%s", service, key)
+	if config.Has(serviceKey) {
+		return serviceKey
+	}
+
+	return "http." + key
+}
+
+// buildServiceTransport returns the shared transport, or a transport of its own when the service routes through a
+// proxy or overrides the TLS settings.
+func buildServiceTransport(service string) (*http.Transport, error) {
+	proxy, _ := config.Get(getServiceHttpKey(service, "proxy")).(string)
+	skipVerify, _ := config.Get(getServiceHttpKey(service, "tls.insecure_skip_verify")).(bool)
+	minVersion, _ := config.Get(getServiceHttpKey(service, "tls.min_version")).(string)
+	serverName, _ := config.Get(getServiceHttpKey(service, "tls.server_name")).(string)
+
+	if proxy == "" && !skipVerify && minVersion == "" && serverName == "" {
+		return getSharedTransport(), nil
+	}
+
+	transport := newTransport()
+
+	if proxy != "" {
+		proxyUrl, err := url.Parse(proxy)
+		if err != nil || !proxyUrl.IsAbs() || proxyUrl.Host == "" {
+			return nil, fmt.Errorf("The proxy of the service '%s' must be an absolute URL.", service)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: skipVerify, ServerName: serverName}
+	if minVersion != "" {
+		version, ok := tlsVersions[minVersion]
+		if !ok {
+			return nil, fmt.Errorf("The minimum TLS version of the service '%s' must be one of 1.0, 1.1, 1.2 or 1.3.", service)
+		}
+
+		tlsConfig.MinVersion = version
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// getServiceClient returns the HTTP client used for every request to the service, configured by the [http] table and
+// the service's [services.x.http] overrides.
+func getServiceClient(service string) (*http.Client, error) {
+	serviceClients.mutex.Lock()
+	defer serviceClients.mutex.Unlock()
+
+	if client, ok := serviceClients.clients[service]; ok {
+		return client, nil
+	}
+
+	transport, err := buildServiceTransport(service)
+	if err != nil {
+		return nil, err
+	}
+
+	userAgent, ok := config.Get(getServiceHttpKey(service, "user_agent")).(string)
+	if !ok {
+		userAgent = fmt.Sprintf("SuggestionsLists/%s", getVersion())
+	}
+
+	roundTripper := &serviceTransport{base: transport, userAgent: userAgent}
+	if concurrency := config.GetDefault(getServiceHttpKey(service, "max_concurrency"), int64(0)).(int64); concurrency > 0 {
+		roundTripper.slots = make(chan struct{}, concurrency)
+	}
+
+	client := &http.Client{
+		Timeout:   getConfigDuration(getServiceHttpKey(service, "timeout"), time.Second*30),
+		Transport: roundTripper,
+	}
+
+	serviceClients.clients[service] = client
+
+	return client, nil
+}
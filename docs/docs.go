@@ -25,166 +25,320 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/guilds": {
+        "/api/public/badges/growth": {
             "get": {
-                "description": "The most recently posted guild and shard count in the database is returned as well as the timestamp of when this data was committed. This data reflects the guild count on the active bot lists.",
+                "description": "Renders the daily closing guild counts over the last days as an SVG sparkline. Days before the first post are left out. This route doesn't require an API key, the sparkline is cached.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "image/svg+xml"
                 ],
                 "tags": [
-                    "General"
+                    "Public"
                 ],
-                "summary": "Get the recent guild count from the database.",
+                "summary": "Get a guild count growth sparkline.",
                 "parameters": [
+                    {
+                        "maximum": 90,
+                        "minimum": 2,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "The amount of days to render.",
+                        "name": "days",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 20,
+                        "type": "integer",
+                        "default": 120,
+                        "description": "The width of the sparkline.",
+                        "name": "width",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 200,
+                        "minimum": 10,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "The height of the sparkline.",
+                        "name": "height",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "The required API key",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "A named color or a hex color without the #.",
+                        "name": "color",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/main.GuildCountResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/main.ErrorResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/main.ResponseHTTPError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "The guild count and shard count are persisted to the database then posted to all active bot lists set in the config.",
+            }
+        },
+        "/api/public/badges/servers": {
+            "get": {
+                "description": "Renders the most recently posted guild count as an SVG badge. This route doesn't require an API key, the badge is cached.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "image/svg+xml"
                 ],
                 "tags": [
-                    "General"
+                    "Public"
                 ],
-                "summary": "Post guild stats to bot lists and persist them in the database.",
+                "summary": "Get a guild count badge.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The required API key",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "default": "servers",
+                        "description": "The text on the left of the badge.",
+                        "name": "label",
+                        "in": "query"
                     },
                     {
-                        "description": "The request body to pass in.",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/main.GuildCountRequestBody"
-                        }
+                        "type": "string",
+                        "description": "A named color or a hex color without the #.",
+                        "name": "color",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "compact",
+                            "full"
+                        ],
+                        "type": "string",
+                        "default": "compact",
+                        "description": "Whether to compact the number.",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/main.GuildCountResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/main.ErrorResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "503": {
-                        "description": "Service Unavailable",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
-                            "$ref": "#/definitions/main.ResponseHTTPError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/services": {
+        "/api/public/badges/votes/{service}": {
             "get": {
-                "description": "This function returns the timestamp of when guild stats were lasted committed to the database as well as an overview of all information from bot lists that are marked active via the config.",
+                "description": "Renders the amount of votes received on the bot list within the period as an SVG badge. The monthly and all time votes are those displayed on the list, while votes for the day and week are counted from the vote webhooks received, as is any period the list doesn't report. This route doesn't require an API key, the badge is cached.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "image/svg+xml"
                 ],
                 "tags": [
-                    "General"
+                    "Public"
                 ],
-                "summary": "Get all active lists the bot is on.",
+                "summary": "Get a vote count badge.",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "The required API key",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "The service to count the votes of.",
+                        "name": "service",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "votes",
+                        "description": "The text on the left of the badge.",
+                        "name": "label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "A named color or a hex color without the #.",
+                        "name": "color",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "compact",
+                            "full"
+                        ],
+                        "type": "string",
+                        "default": "compact",
+                        "description": "Whether to compact the number.",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "The period to count the votes in.",
+                        "name": "period",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/main.BotListServicesResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/main.ErrorResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/main.ResponseHTTPError"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     }
                 }
             }
         },
-        "/api/v1/services/{service}": {
+        "/api/public/guilds": {
             "get": {
-                "description": "This function returns the timestamp of when guild stats were lasted committed to the database as well as an overview of the specific bot list the bot is on.",
+                "description": "Returns the most recently posted guild count and when it was posted. This route doesn't require an API key, the data is cached.",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,25 +346,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "General"
+                    "Public"
                 ],
-                "summary": "Get a single list the bot is on.",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "The required API key",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                "summary": "Get the latest guild count.",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.PublicGuildCountResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "The bot list service to get information from.",
-                        "name": "service",
-                        "in": "path",
-                        "required": true
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     }
+                }
+            }
+        },
+        "/api/public/services": {
+            "get": {
+                "description": "Returns the links to every active bot list and the guild count each list displays. This route doesn't require an API key, the data is cached.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public"
                 ],
+                "summary": "Get all active lists the bot is on.",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -223,15 +432,18 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/main.BotListServicesResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/main.PublicServiceResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "429": {
+                        "description": "Too Many Requests",
                         "schema": {
                             "allOf": [
                                 {
@@ -241,15 +453,15 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/main.InvalidServiceError"
+                                            "$ref": "#/definitions/main.DefaultFiberError"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "503": {
+                        "description": "Service Unavailable",
                         "schema": {
                             "allOf": [
                                 {
@@ -268,138 +480,3649 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "main.BotListServiceResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "boolean",
-                    "example": false
-                },
-                "guild_count": {
-                    "type": "integer",
-                    "example": 50000
-                },
-                "short_name": {
-                    "type": "string",
-                    "example": "topgg"
-                },
-                "url": {
-                    "type": "string",
-                    "example": "https://top.gg"
-                }
+        },
+        "/api/v1/admin/audit": {
+            "get": {
+                "description": "Returns every mutating request made with an API key, most recent first. Each entry includes the name of the key, the IP, the route, the request body with secrets redacted and the result.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get the audit log.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include requests made with the API key with this name.",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "POST",
+                            "PUT",
+                            "PATCH",
+                            "DELETE"
+                        ],
+                        "type": "string",
+                        "description": "Only include requests with this method.",
+                        "name": "method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only include requests to this route, such as /api/v1/subscriptions/:id.",
+                        "name": "route",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include requests that resulted in this status code.",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include requests made at or after this Unix timestamp in milliseconds.",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include requests made before this Unix timestamp in milliseconds.",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "The page to return.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "The amount of entries per page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.AuditLogResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/services": {
+            "get": {
+                "description": "Returns whether every bot list in the config is enabled there, whether it is currently active and the override set through the admin routes, if any. Overrides take precedence over the config.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get the status of all configured lists.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/main.AdminServiceStatus"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/services/{service}/audit": {
+            "get": {
+                "description": "Returns which API key enabled, disabled, paused or reset the bot list through the admin routes and when, most recent first.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get the audit log of a list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bot list service to get the audit log of.",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 50,
+                        "description": "The maximum amount of entries to return.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/main.ServiceOverrideAuditEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/services/{service}/disable": {
+            "post": {
+                "description": "Disables the bot list regardless of its enabled flag in the config, lifting any pause. Stats aren't posted to or fetched from disabled lists. The change is recorded in the service's audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Disable a list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bot list service to disable.",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The request body to pass in.",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/main.ServiceOverrideRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.ServiceOverride"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/services/{service}/enable": {
+            "post": {
+                "description": "Enables the bot list regardless of its enabled flag in the config, lifting any pause. The change is recorded in the service's audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Enable a list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bot list service to enable.",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The request body to pass in.",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/main.ServiceOverrideRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.ServiceOverride"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/services/{service}/override": {
+            "delete": {
+                "description": "Removes the override set through the admin routes, so that whether the bot list is active follows its enabled flag in the config again. The change is recorded in the service's audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Reset a list to its config.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bot list service to reset.",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The request body to pass in.",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/main.ServiceOverrideRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTP"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/services/{service}/pause": {
+            "post": {
+                "description": "Pauses the bot list either until the given Unix timestamp in milliseconds or for the given duration, such as 2h. The list is inactive while paused and becomes active again on its own afterwards. The change is recorded in the service's audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Pause a list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bot list service to pause.",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The request body to pass in.",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ServicePauseRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.ServiceOverride"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/admin/tokens": {
+            "get": {
+                "description": "Sends a harmless authenticated request to every active bot list and reports whether its token is missing, invalid or accepted. Each list is checked against its token_check_url, an endpoint which rejects bad tokens, and lists without one are reported as unknown.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Check the tokens of all active lists.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/main.TokenCheckResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/deliveries/{id}/redeliver": {
+            "post": {
+                "description": "A new delivery of the same event is queued for the same subscription, the original delivery stays in the log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Redeliver an event.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The id of the delivery to redeliver.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.EventDelivery"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/events/stream": {
+            "get": {
+                "description": "Streams every event matching the event types as Server-Sent Events, an empty list of event types streams all events. Each event's id is sent along with it, reconnecting with the Last-Event-ID header replays the events missed in between from the event log. Heartbeat comments are sent while no events are emitted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Stream events in real time.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The id of the last event received, to resume from.",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "The event types to stream.",
+                        "name": "events",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/guilds": {
+            "get": {
+                "description": "The most recently posted guild and shard count in the database is returned as well as the timestamp of when this data was committed. This data reflects the guild count on the active bot lists. If a per-shard breakdown was posted, it is included as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Get the recent guild count from the database.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.GuildCountResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "The guild count and shard count (optionally broken down per shard) along with any additional stats are persisted to the database then posted to all active bot lists set in the config. Each list receives the stats mapped in its fields config. The targeted lists can be narrowed down with an include and exclude list of service names or config-defined service groups. When dry_run is set nothing is persisted or posted, instead a preview of the exact request that would be sent to each targeted list is returned with the token redacted.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Post guild stats to bot lists and persist them in the database.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "The request body to pass in.",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.GuildCountRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.GuildCountResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/guilds/stats": {
+            "get": {
+                "description": "Returns the net change and growth rate over the last 24 hours, 7 days and 30 days, estimated daily joins and leaves for the last 30 days with each day's closing count and its 7 day moving average, and a projection of when the next milestone will be reached based on a linear regression over the last 30 days. The projection is omitted when the milestone is more than 10 years away at the current rate.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Get growth analytics for the guild count.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.GuildStatsResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/milestones": {
+            "get": {
+                "description": "Milestones are the thresholds listed in the config as well as every multiple of the configured step. They are detected when a posted guild count crosses them, and are only ever recorded once. The next milestone to be reached is returned as well.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Get all guild count milestones reached.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.MilestonesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reminders": {
+            "post": {
+                "description": "Once opted in, every vote the user casts schedules a reminder for when the bot list's cooldown expires. The reminder is emitted as a vote.reminder event, delivered to the configured reminders URL and the event subscriptions listening for it. Reminders can be limited to specific bot lists, by default all lists accepting votes are included. Opting in again replaces the list of services.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Votes"
+                ],
+                "summary": "Opt a user in to vote reminders.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "The request body to pass in.",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.ReminderSubscriptionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.ReminderSubscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reminders/{user}": {
+            "get": {
+                "description": "Returns the bot lists the user is reminded to vote on as well as their reminders that have yet to be delivered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Votes"
+                ],
+                "summary": "Get a user's reminder subscription.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The user to get the reminder subscription of.",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.ReminderSubscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "The user's subscription is removed along with any of their reminders that have yet to be delivered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Votes"
+                ],
+                "summary": "Opt a user out of vote reminders.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The user to opt out of reminders.",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTP"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/services": {
+            "get": {
+                "description": "This function returns the timestamp of when guild stats were lasted committed to the database as well as an overview of all information from bot lists that are marked active via the config. The bot list data is cached, cache_age is how old the returned data is in milliseconds. Stale data is served while it is refreshed in the background. Each service includes the state of its circuit breaker, which skips requests to a list after it failed too many times in a row.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Get all active lists the bot is on.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Bypass the cache and fetch live data from every bot list.",
+                        "name": "fresh",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.BotListServicesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/services/{service}": {
+            "get": {
+                "description": "This function returns the timestamp of when guild stats were lasted committed to the database as well as an overview of the specific bot list the bot is on.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Get a single list the bot is on.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bot list service to get information from.",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.BotListServicesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.InvalidServiceError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/services/{service}/history": {
+            "get": {
+                "description": "Returns the snapshots periodically taken of the stats the bot list displays, such as its guild count, votes and rating, oldest first. Defaults to the last 30 days, only the most recent snapshots are returned when there are more than the limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Get the history of a list the bot is on.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bot list service to get the history of.",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include snapshots taken at or after this Unix timestamp in milliseconds.",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only include snapshots taken before this Unix timestamp in milliseconds.",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 500,
+                        "description": "The maximum amount of snapshots to return.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.ListHistoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscriptions": {
+            "get": {
+                "description": "Returns every subscription without its secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get all event subscriptions.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/main.Subscription"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Every event matching the subscription's event types is delivered to its URL as a POST request, an empty list of event types subscribes to all events. Deliveries are signed with an HMAC-SHA256 of the X-Lists-Timestamp header, a period and the body using the subscription's secret, sent in the X-Lists-Signature header. A secret is generated if one isn't passed in, it is only ever returned here. Failed deliveries are retried with backoff.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Subscribe an endpoint to events.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "The request body to pass in.",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/main.SubscriptionRequestBody"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.Subscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscriptions/{id}": {
+            "get": {
+                "description": "Returns the subscription without its secret.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get an event subscription.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The id of the subscription.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.Subscription"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "The subscription is deleted along with its delivery log, pending deliveries are dropped.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Delete an event subscription.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The id of the subscription.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTP"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/subscriptions/{id}/deliveries": {
+            "get": {
+                "description": "Deliveries are returned most recent first along with the status code the subscriber responded with and the last error. Results are paginated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get the delivery log of an event subscription.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "The id of the subscription.",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Only return deliveries with this status.",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "The page of deliveries to return.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "The amount of deliveries per page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DeliveriesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/votes": {
+            "get": {
+                "description": "Votes are returned most recent first and can be filtered by service, user and a time range. Results are paginated.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Votes"
+                ],
+                "summary": "Get recent votes.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return votes from this bot list service.",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return votes from this user.",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return votes at or after this unix timestamp in milliseconds.",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return votes before this unix timestamp in milliseconds.",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "The page of votes to return.",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "The amount of votes per page.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.VotesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/votes/leaderboard": {
+            "get": {
+                "description": "Voters are ranked by points, where every vote is worth a point and weekend votes are worth the weekend multiplier set for the bot list they were cast on. Periods are aligned to UTC, an explicit from or to overrides the start or end of the period.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Votes"
+                ],
+                "summary": "Get the top voters over a period.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "The period to rank voters over.",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only count votes from this bot list service.",
+                        "name": "service",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Override the start of the period with a unix timestamp in milliseconds.",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Override the end of the period with a unix timestamp in milliseconds.",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "The amount of voters to return.",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.LeaderboardResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/main.ResponseHTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/votes/users/{user}": {
+            "get": {
+                "description": "For every bot list accepting votes, this returns when the user last voted, whether they are still within the list's vote cooldown and when they are next able to vote. has_voted is true if the user is within the cooldown of any list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Votes"
+                ],
+                "summary": "Get the vote status of a user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The user to get the vote status of.",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.UserVoteStatusResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/votes/users/{user}/streak": {
+            "get": {
+                "description": "The daily streak counts consecutive UTC days the user voted on any bot list. Each bot list also has a streak of consecutive cooldown windows voted, which breaks once the user goes twice the list's cooldown without voting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Votes"
+                ],
+                "summary": "Get the voting streaks of a user.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The required API key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The user to get the voting streaks of.",
+                        "name": "user",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.UserVoteStreakResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/api/webhooks/votes/{service}": {
+            "post": {
+                "description": "Bot lists call this route whenever a user votes. The request must be authorized with the webhook token set for the service, the voting user is pulled from the payload using the service's vote config and persisted to the database.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Votes"
+                ],
+                "summary": "Receive a vote webhook from a bot list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The webhook token set for the bot list",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The bot list service sending the vote.",
+                        "name": "service",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTP"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.Vote"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/main.ResponseHTTPError"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/main.DefaultFiberError"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "main.AdminServiceStatus": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "config_enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "override": {
+                    "$ref": "#/definitions/main.ServiceOverride"
+                },
+                "service": {
+                    "type": "string",
+                    "example": "discords"
+                }
+            }
+        },
+        "main.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "{\"guild_count\":50000,\"shard_count\":50}"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "The service 'topgg' is not a valid service."
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "ip": {
+                    "type": "string",
+                    "example": "127.0.0.1"
+                },
+                "key_name": {
+                    "type": "string",
+                    "example": "dashboard"
+                },
+                "method": {
+                    "type": "string",
+                    "example": "POST"
+                },
+                "path": {
+                    "type": "string",
+                    "example": "/api/v1/guilds"
+                },
+                "route": {
+                    "type": "string",
+                    "example": "/api/v1/guilds"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                }
+            }
+        },
+        "main.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.AuditEntry"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1200
+                }
+            }
+        },
+        "main.BotListServiceResponse": {
+            "type": "object",
+            "properties": {
+                "breaker": {
+                    "$ref": "#/definitions/main.BreakerStatus"
+                },
+                "error": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "extraction_errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ExtractionError"
+                    }
+                },
+                "guild_count": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "monthly_votes": {
+                    "type": "integer",
+                    "example": 800
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.8
+                },
+                "shard_count": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "short_name": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://top.gg"
+                },
+                "votes": {
+                    "type": "integer",
+                    "example": 12000
+                }
+            }
+        },
+        "main.BotListServicesResponse": {
+            "type": "object",
+            "properties": {
+                "cache_age": {
+                    "type": "integer",
+                    "example": 12000
+                },
+                "last_updated": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.BotListServiceResponse"
+                    }
+                }
+            }
+        },
+        "main.BreakerStatus": {
+            "type": "object",
+            "properties": {
+                "failures": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "opened_at": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "retry_at": {
+                    "type": "integer",
+                    "example": 1671940451185
+                },
+                "state": {
+                    "type": "string",
+                    "example": "open"
+                }
+            }
+        },
+        "main.DailyGrowth": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "date": {
+                    "type": "string",
+                    "example": "2022-12-25"
+                },
+                "joins": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "leaves": {
+                    "type": "integer",
+                    "example": 45
+                },
+                "moving_average": {
+                    "type": "number",
+                    "example": 49800.5
+                },
+                "net_change": {
+                    "type": "integer",
+                    "example": 75
+                }
+            }
+        },
+        "main.DefaultFiberError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "message": {
+                    "type": "string",
+                    "example": "missing or malformed API Key"
+                }
+            }
+        },
+        "main.DeliveriesResponse": {
+            "type": "object",
+            "properties": {
+                "deliveries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.EventDelivery"
+                    }
+                },
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 120
+                }
+            }
+        },
+        "main.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "failedField": {
+                    "type": "string"
+                },
+                "tag": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "main.Event": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "type": {
+                    "type": "string",
+                    "example": "vote.received"
+                }
+            }
+        },
+        "main.EventDelivery": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "delivered_at": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "error": {
+                    "type": "string",
+                    "example": "The subscriber responded with status 500."
+                },
+                "event": {
+                    "type": "string",
+                    "example": "vote.received"
+                },
+                "event_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "response_code": {
+                    "type": "integer",
+                    "example": 200
+                },
+                "status": {
+                    "type": "string",
+                    "example": "delivered"
+                },
+                "subscription_id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                }
+            }
+        },
+        "main.ExtractionError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string",
+                    "example": "guild_count"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Couldn't extract 'guild_count' at '$.server_count': expected a number but found null."
+                },
+                "path": {
+                    "type": "string",
+                    "example": "$.server_count"
+                },
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                }
+            }
+        },
+        "main.GrowthProjection": {
+            "type": "object",
+            "properties": {
+                "daily_growth": {
+                    "type": "number",
+                    "example": 71.4
+                },
+                "estimated_at": {
+                    "type": "integer",
+                    "example": 1706940391185
+                },
+                "next_milestone": {
+                    "type": "integer",
+                    "example": 75000
+                }
+            }
+        },
+        "main.GrowthWindow": {
+            "type": "object",
+            "properties": {
+                "end": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "growth_rate": {
+                    "type": "number",
+                    "example": 1.01
+                },
+                "net_change": {
+                    "type": "integer",
+                    "example": 500
+                },
+                "start": {
+                    "type": "integer",
+                    "example": 49500
+                },
+                "window": {
+                    "type": "string",
+                    "example": "7d"
+                }
+            }
+        },
+        "main.GuildCountRequestBody": {
+            "type": "object",
+            "required": [
+                "guild_count",
+                "shard_count",
+                "stats"
+            ],
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "guild_count": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "services": {
+                    "$ref": "#/definitions/main.ServiceSelection"
+                },
+                "shard_count": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "example": 50
+                },
+                "shard_stats": {
+                    "type": "array",
+                    "maxItems": 10000,
+                    "uniqueItems": true,
+                    "items": {
+                        "$ref": "#/definitions/main.ShardGuildCount"
+                    }
+                },
+                "stats": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "main.GuildCountResponse": {
+            "type": "object",
+            "properties": {
+                "dry_run": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "guild_count": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "previews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ServiceRequestPreview"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "topgg"
+                    ]
+                },
+                "shard_count": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "shard_stats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ShardGuildCount"
+                    }
+                },
+                "stats": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                }
+            }
+        },
+        "main.GuildStatsResponse": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.DailyGrowth"
+                    }
+                },
+                "guild_count": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "projection": {
+                    "$ref": "#/definitions/main.GrowthProjection"
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.GrowthWindow"
+                    }
+                }
+            }
+        },
+        "main.InvalidServiceError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer",
+                    "example": 400
+                },
+                "message": {
+                    "type": "string",
+                    "example": "The service 'memelist' is not a valid service."
+                }
+            }
+        },
+        "main.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "points": {
+                    "type": "number",
+                    "example": 52
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "158063324699951104"
+                },
+                "votes": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "main.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.LeaderboardEntry"
+                    }
+                },
+                "from": {
+                    "type": "integer",
+                    "example": 1669852800000
+                },
+                "period": {
+                    "type": "string",
+                    "example": "month"
+                },
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 1671940391185
+                }
+            }
+        },
+        "main.ListHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "integer",
+                    "example": 1669348391185
+                },
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "snapshots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ListSnapshot"
+                    }
+                },
+                "to": {
+                    "type": "integer",
+                    "example": 1671940391185
+                }
+            }
+        },
+        "main.ListSnapshot": {
+            "type": "object",
+            "properties": {
+                "guild_count": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "monthly_votes": {
+                    "type": "integer",
+                    "example": 800
+                },
+                "rank": {
+                    "type": "integer",
+                    "example": 120
+                },
+                "rating": {
+                    "type": "number",
+                    "example": 4.8
+                },
+                "shard_count": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "votes": {
+                    "type": "integer",
+                    "example": 12000
+                }
+            }
+        },
+        "main.Milestone": {
+            "type": "object",
+            "properties": {
+                "guild_count": {
+                    "type": "integer",
+                    "example": 50012
+                },
+                "milestone": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "previous_guild_count": {
+                    "type": "integer",
+                    "example": 49987
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                }
+            }
+        },
+        "main.MilestonesResponse": {
+            "type": "object",
+            "properties": {
+                "milestones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Milestone"
+                    }
+                },
+                "next": {
+                    "type": "integer",
+                    "example": 75000
+                }
+            }
+        },
+        "main.PublicGuildCountResponse": {
+            "type": "object",
+            "properties": {
+                "guild_count": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                }
+            }
+        },
+        "main.PublicServiceResponse": {
+            "type": "object",
+            "properties": {
+                "guild_count": {
+                    "type": "integer",
+                    "example": 50000
+                },
+                "long_name": {
+                    "type": "string",
+                    "example": "Top.gg"
+                },
+                "short_name": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://top.gg"
+                },
+                "vote_url": {
+                    "type": "string",
+                    "example": "https://top.gg/bot/474051954998509571/vote"
+                }
+            }
+        },
+        "main.ReminderSubscription": {
+            "type": "object",
+            "properties": {
+                "pending": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.VoteReminder"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "topgg"
+                    ]
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "158063324699951104"
+                }
+            }
+        },
+        "main.ReminderSubscriptionRequestBody": {
+            "type": "object",
+            "required": [
+                "services",
+                "user_id"
+            ],
+            "properties": {
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "topgg"
+                    ]
+                },
+                "user_id": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "158063324699951104"
+                }
+            }
+        },
+        "main.ResponseHTTP": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "nonce": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "main.ResponseHTTPError": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "nonce": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": false
+                }
+            }
+        },
+        "main.ServiceOverride": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "paused_until": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Discords.com is down."
+                },
+                "service": {
+                    "type": "string",
+                    "example": "discords"
+                },
+                "updated_at": {
+                    "type": "integer",
+                    "example": 1671940391185
+                }
             }
         },
-        "main.BotListServicesResponse": {
+        "main.ServiceOverrideAuditEntry": {
             "type": "object",
             "properties": {
-                "last_updated": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "enabled",
+                        "disabled",
+                        "paused",
+                        "reset"
+                    ],
+                    "example": "paused"
+                },
+                "actor": {
+                    "type": "string",
+                    "example": "dashboard"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "paused_until": {
                     "type": "integer",
                     "example": 1671940391185
                 },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/main.BotListServiceResponse"
-                    }
+                "reason": {
+                    "type": "string",
+                    "example": "Discords.com is down."
+                },
+                "service": {
+                    "type": "string",
+                    "example": "discords"
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
                 }
             }
         },
-        "main.DefaultFiberError": {
+        "main.ServiceOverrideRequestBody": {
             "type": "object",
             "properties": {
-                "code": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "example": "Discords.com is down."
+                }
+            }
+        },
+        "main.ServicePauseRequestBody": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string",
+                    "example": "2h"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 256,
+                    "example": "Discords.com is down."
+                },
+                "until": {
                     "type": "integer",
-                    "example": 500
+                    "minimum": 0,
+                    "example": 1671940391185
+                }
+            }
+        },
+        "main.ServiceRequestPreview": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "object"
                 },
-                "message": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "method": {
                     "type": "string",
-                    "example": "missing or malformed API Key"
+                    "example": "POST"
+                },
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://top.gg/api/bots/474051954998509571/stats"
+                },
+                "valid": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "warnings": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "main.GuildCountRequestBody": {
+        "main.ServiceSelection": {
             "type": "object",
             "required": [
-                "guild_count",
-                "shard_count"
+                "exclude",
+                "include"
             ],
             "properties": {
-                "dry_run": {
+                "exclude": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "discords"
+                    ]
+                },
+                "include": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "primary"
+                    ]
+                }
+            }
+        },
+        "main.ServiceVoteStatus": {
+            "type": "object",
+            "properties": {
+                "can_vote": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "cooldown": {
+                    "type": "integer",
+                    "example": 43200000
+                },
+                "last_voted_at": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "next_vote_at": {
+                    "type": "integer",
+                    "example": 1671983591185
+                },
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "voted": {
                     "type": "boolean",
                     "example": true
+                }
+            }
+        },
+        "main.ServiceVoteStreak": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "last_voted_at": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "longest": {
+                    "type": "integer",
+                    "example": 12
                 },
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                }
+            }
+        },
+        "main.ShardGuildCount": {
+            "type": "object",
+            "properties": {
                 "guild_count": {
                     "type": "integer",
-                    "example": 50000
+                    "minimum": 0,
+                    "example": 1000
                 },
-                "shard_count": {
+                "shard_id": {
                     "type": "integer",
-                    "example": 50
+                    "minimum": 0,
+                    "example": 0
                 }
             }
         },
-        "main.GuildCountResponse": {
+        "main.Subscription": {
             "type": "object",
             "properties": {
-                "dry_run": {
+                "enabled": {
                     "type": "boolean",
-                    "example": false
+                    "example": true
                 },
-                "guild_count": {
-                    "type": "integer",
-                    "example": 50000
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vote.received"
+                    ]
                 },
-                "shard_count": {
+                "id": {
                     "type": "integer",
-                    "example": 50
+                    "example": 1
+                },
+                "secret": {
+                    "type": "string",
+                    "example": "5f2b1c0e6f1d4c2a9b7e3d8c1a0f6e4b5f2b1c0e6f1d4c2a9b7e3d8c1a0f6e4b"
                 },
                 "timestamp": {
                     "type": "integer",
                     "example": 1671940391185
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://suggestions.gg/api/webhooks/lists"
                 }
             }
         },
-        "main.InvalidServiceError": {
+        "main.SubscriptionRequestBody": {
             "type": "object",
+            "required": [
+                "events",
+                "url"
+            ],
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "vote.received"
+                    ]
                 },
-                "message": {
+                "secret": {
                     "type": "string",
-                    "example": "The service 'memelist' is not a valid service."
+                    "minLength": 16,
+                    "example": "a-secret-of-at-least-16-characters"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://suggestions.gg/api/webhooks/lists"
                 }
             }
         },
-        "main.ResponseHTTP": {
+        "main.TokenCheckResult": {
             "type": "object",
             "properties": {
-                "data": {},
-                "nonce": {
-                    "type": "integer",
-                    "example": 1671940391185
+                "message": {
+                    "type": "string",
+                    "example": "The bot list rejected the token with status 401, it is invalid or has expired."
                 },
-                "success": {
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "ok",
+                        "missing",
+                        "invalid",
+                        "not_required",
+                        "unreachable",
+                        "unknown"
+                    ],
+                    "example": "invalid"
+                },
+                "status_code": {
+                    "type": "integer",
+                    "example": 401
+                }
+            }
+        },
+        "main.UserVoteStatusResponse": {
+            "type": "object",
+            "properties": {
+                "has_voted": {
                     "type": "boolean",
                     "example": true
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ServiceVoteStatus"
+                    }
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "158063324699951104"
                 }
             }
         },
-        "main.ResponseHTTPError": {
+        "main.UserVoteStreakResponse": {
             "type": "object",
             "properties": {
-                "data": {},
-                "nonce": {
+                "daily": {
+                    "$ref": "#/definitions/main.VoteStreak"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.ServiceVoteStreak"
+                    }
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "158063324699951104"
+                }
+            }
+        },
+        "main.Vote": {
+            "type": "object",
+            "properties": {
+                "id": {
                     "type": "integer",
-                    "example": 1671940391185
+                    "example": 1
                 },
-                "success": {
+                "is_weekend": {
                     "type": "boolean",
                     "example": false
+                },
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "timestamp": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "158063324699951104"
+                }
+            }
+        },
+        "main.VoteReminder": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "remind_at": {
+                    "type": "integer",
+                    "example": 1671983591185
+                },
+                "service": {
+                    "type": "string",
+                    "example": "topgg"
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "158063324699951104"
+                }
+            }
+        },
+        "main.VoteStreak": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "last_voted_at": {
+                    "type": "integer",
+                    "example": 1671940391185
+                },
+                "longest": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "main.VotesResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "page": {
+                    "type": "integer",
+                    "example": 1
+                },
+                "total": {
+                    "type": "integer",
+                    "example": 1200
+                },
+                "votes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/main.Vote"
+                    }
                 }
             }
         }
@@ -408,6 +4131,22 @@ const docTemplate = `{
         {
             "description": "All routes for the service.",
             "name": "General"
+        },
+        {
+            "description": "Routes for receiving and querying votes from bot lists.",
+            "name": "Votes"
+        },
+        {
+            "description": "Unauthenticated read-only routes serving cached data for the website.",
+            "name": "Public"
+        },
+        {
+            "description": "Routes for managing subscriptions to events delivered as webhooks.",
+            "name": "Events"
+        },
+        {
+            "description": "Routes for operating the service.",
+            "name": "Admin"
         }
     ]
 }`
@@ -0,0 +1,428 @@
+package main
+
+import (
+	bytes2 "bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// eventTypes are all events subscribers can filter on. A subscription without any event types receives all of them.
+var eventTypes = []string{
+	"vote.received",
+	"guilds.posted",
+	"service.posted",
+	"service.post_failed",
+	"service.drift",
+	"milestone.reached",
+	"vote.reminder",
+}
+
+func isEventType(eventType string) bool {
+	return containsString(eventTypes, eventType)
+}
+
+// emitEvent persists the event, queues a delivery for every enabled subscription listening for its type and publishes
+// it to the connected event streams.
+func emitEvent(eventType string, data interface{}) (*Event, error) {
+	var event *Event
+
+	err := conn.BeginFunc(context.Background(), func(tx pgx.Tx) error {
+		var insertErr error
+		event, insertErr = insertEvent(tx, eventType, data)
+
+		return insertErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	broker.publish(*event)
+
+	return event, nil
+}
+
+// insertEvent persists the event and queues its deliveries within the transaction. Publishing it to the event streams
+// is left to the caller once the transaction is committed.
+func insertEvent(tx pgx.Tx, eventType string, data interface{}) (*Event, error) {
+	event := &Event{Type: eventType}
+
+	payload, jsonErr := json.Marshal(data)
+	if jsonErr != nil {
+		return nil, jsonErr
+	}
+
+	event.Data = payload
+
+	var createdAt time.Time
+	query := "insert into events(type, payload) values ($1, $2) returning id, created_at"
+	scanErr := tx.QueryRow(context.Background(), query, eventType, string(payload)).Scan(&event.Id, &createdAt)
+	if scanErr != nil {
+		return nil, scanErr
+	}
+
+	event.Timestamp = createdAt.UnixMilli()
+
+	deliveryQuery := `insert into event_deliveries(subscription_id, event_id)
+		select id, $1 from subscriptions
+		where enabled and (events is null or cardinality(events) = 0 or $2 = any(events))`
+	if _, execErr := tx.Exec(context.Background(), deliveryQuery, event.Id, eventType); execErr != nil {
+		return nil, execErr
+	}
+
+	return event, nil
+}
+
+// emitEventAsync emits the event without holding up the caller, logging if it couldn't be persisted.
+func emitEventAsync(eventType string, data interface{}) {
+	go func() {
+		if _, err := emitEvent(eventType, data); err != nil {
+			log.Printf("Failed to emit the %s event: %s", eventType, err)
+		}
+	}()
+}
+
+// generateSecret returns a random hex encoded secret used to sign deliveries.
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(secret), nil
+}
+
+// signPayload signs the timestamp and body of a delivery with the subscription's secret, allowing subscribers to
+// verify deliveries and reject replayed ones.
+func signPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func insertSubscription(body SubscriptionRequestBody) (*Subscription, error) {
+	secret := body.Secret
+	if secret == "" {
+		generated, err := generateSecret()
+		if err != nil {
+			return nil, err
+		}
+
+		secret = generated
+	}
+
+	subscription := &Subscription{Url: body.Url, Events: body.Events, Enabled: true, Secret: secret}
+	if subscription.Events == nil {
+		subscription.Events = make([]string, 0)
+	}
+
+	var createdAt time.Time
+	query := "insert into subscriptions(url, secret, events) values ($1, $2, $3) returning id, created_at"
+	err := conn.QueryRow(context.Background(), query, body.Url, secret, subscription.Events).Scan(&subscription.Id, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Timestamp = createdAt.UnixMilli()
+
+	return subscription, nil
+}
+
+func scanSubscription(row pgx.Row) (*Subscription, error) {
+	var subscription Subscription
+	var createdAt time.Time
+	err := row.Scan(&subscription.Id, &subscription.Url, &subscription.Events, &subscription.Enabled, &createdAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription.Events == nil {
+		subscription.Events = make([]string, 0)
+	}
+
+	subscription.Timestamp = createdAt.UnixMilli()
+
+	return &subscription, nil
+}
+
+func getSubscriptions() ([]Subscription, error) {
+	rows, err := queryRows("select id, url, events, enabled, created_at from subscriptions order by id")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	subscriptions := make([]Subscription, 0)
+	for rows.Next() {
+		subscription, scanErr := scanSubscription(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		subscriptions = append(subscriptions, *subscription)
+	}
+
+	return subscriptions, rows.Err()
+}
+
+// getSubscription returns the subscription with the id, or nil if it doesn't exist.
+func getSubscription(id int64) (*Subscription, error) {
+	query := "select id, url, events, enabled, created_at from subscriptions where id = $1"
+	subscription, err := scanSubscription(conn.QueryRow(context.Background(), query, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return subscription, err
+}
+
+func deleteSubscription(id int64) (bool, error) {
+	tag, err := execQuery("delete from subscriptions where id = $1", id)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+const deliveryColumns = `d.id, d.subscription_id, d.event_id, e.type, d.status, d.attempts, d.response_code, d.last_error,
+	d.delivered_at, d.created_at`
+
+func scanDelivery(row pgx.Row) (*EventDelivery, error) {
+	var delivery EventDelivery
+	var responseCode *int64
+	var lastError *string
+	var deliveredAt *time.Time
+	var createdAt time.Time
+
+	err := row.Scan(
+		&delivery.Id, &delivery.SubscriptionId, &delivery.EventId, &delivery.Event, &delivery.Status,
+		&delivery.Attempts, &responseCode, &lastError, &deliveredAt, &createdAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if responseCode != nil {
+		delivery.ResponseCode = *responseCode
+	}
+
+	if lastError != nil {
+		delivery.Error = *lastError
+	}
+
+	if deliveredAt != nil {
+		delivery.DeliveredAt = deliveredAt.UnixMilli()
+	}
+
+	delivery.Timestamp = createdAt.UnixMilli()
+
+	return &delivery, nil
+}
+
+// getDeliveries returns a page of the subscription's delivery log, most recent first.
+func getDeliveries(subscriptionId int64, filter DeliveryQuery) ([]EventDelivery, int64, error) {
+	args := []interface{}{subscriptionId}
+	where := " where d.subscription_id = $1"
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		where += " and d.status = $2"
+	}
+
+	var total int64
+	countErr := conn.QueryRow(context.Background(), "select count(*) from event_deliveries d"+where, args...).Scan(&total)
+	if countErr != nil {
+		return nil, 0, countErr
+	}
+
+	query := fmt.Sprintf(
+		"select %s from event_deliveries d join events e on e.id = d.event_id%s order by d.created_at desc limit $%d offset $%d",
+		deliveryColumns, where, len(args)+1, len(args)+2,
+	)
+	rows, err := queryRows(query, append(args, filter.Limit, (filter.Page-1)*filter.Limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]EventDelivery, 0)
+	for rows.Next() {
+		delivery, scanErr := scanDelivery(rows)
+		if scanErr != nil {
+			return nil, 0, scanErr
+		}
+
+		deliveries = append(deliveries, *delivery)
+	}
+
+	return deliveries, total, rows.Err()
+}
+
+// redeliver queues a new delivery of the same event to the same subscription, leaving the original delivery in the log.
+// It returns nil if the delivery doesn't exist.
+func redeliver(deliveryId int64) (*EventDelivery, error) {
+	query := `with redelivery as (
+			insert into event_deliveries(subscription_id, event_id)
+			select subscription_id, event_id from event_deliveries where id = $1
+			returning *
+		)
+		select ` + deliveryColumns + ` from redelivery d join events e on e.id = d.event_id`
+	delivery, err := scanDelivery(conn.QueryRow(context.Background(), query, deliveryId))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return delivery, err
+}
+
+// startEventDeliverer periodically delivers pending events to their subscribers.
+func startEventDeliverer() {
+	interval := getConfigDuration("events.interval", time.Second*10)
+
+	go func() {
+		for {
+			if err := deliverPendingEvents(); err != nil {
+				log.Printf("Failed to deliver events: %s", err)
+			}
+
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// deliverPendingEvents claims a batch of pending deliveries and sends them. Claiming a delivery counts as an attempt
+// and pushes back its next attempt exponentially, so failed deliveries are retried with backoff. Deliveries that are
+// still failing after the max attempts are marked as failed.
+func deliverPendingEvents() error {
+	backoff := getConfigDuration("events.retry_backoff", time.Second*30)
+	maxAttempts := config.GetDefault("events.max_attempts", int64(8)).(int64)
+
+	query := `update event_deliveries d
+		set attempts = d.attempts + 1,
+			next_attempt_at = (now() at time zone ('utc')) + make_interval(secs => $1 * power(2, d.attempts))
+		from events e, subscriptions s
+		where e.id = d.event_id and s.id = d.subscription_id and d.id in (
+			select id from event_deliveries
+			where status = 'pending' and next_attempt_at <= (now() at time zone ('utc'))
+			order by next_attempt_at
+			limit 50
+			for update skip locked
+		)
+		returning d.id, d.attempts, e.id, e.type, e.payload, e.created_at, s.url, s.secret`
+	rows, err := queryRows(query, backoff.Seconds())
+	if err != nil {
+		return err
+	}
+
+	type pendingDelivery struct {
+		id       int64
+		attempts int64
+		event    Event
+		url      string
+		secret   string
+	}
+
+	var pending []pendingDelivery
+	for rows.Next() {
+		var p pendingDelivery
+		var payload string
+		var createdAt time.Time
+		scanErr := rows.Scan(&p.id, &p.attempts, &p.event.Id, &p.event.Type, &payload, &createdAt, &p.url, &p.secret)
+		if scanErr != nil {
+			rows.Close()
+			return scanErr
+		}
+
+		p.event.Data = json.RawMessage(payload)
+		p.event.Timestamp = createdAt.UnixMilli()
+		pending = append(pending, p)
+	}
+
+	rows.Close()
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return rowsErr
+	}
+
+	client := &http.Client{Timeout: time.Second * 30}
+
+	for _, p := range pending {
+		statusCode, deliveryErr := deliverEvent(client, p.id, p.url, p.secret, p.event)
+		if deliveryErr != nil {
+			status := "pending"
+			if p.attempts >= maxAttempts {
+				status = "failed"
+			}
+
+			_, execErr := execQuery(
+				"update event_deliveries set status = $2, response_code = $3, last_error = $4 where id = $1",
+				p.id, status, statusCode, deliveryErr.Error(),
+			)
+			if execErr != nil {
+				return execErr
+			}
+
+			continue
+		}
+
+		_, execErr := execQuery(
+			`update event_deliveries set status = 'delivered', response_code = $2, last_error = null,
+				delivered_at = (now() at time zone ('utc')) where id = $1`,
+			p.id, statusCode,
+		)
+		if execErr != nil {
+			return execErr
+		}
+	}
+
+	return nil
+}
+
+// deliverEvent sends the event to the subscriber, returning the status code it responded with. Any status code outside
+// of 2xx is treated as a failed delivery.
+func deliverEvent(httpClient *http.Client, deliveryId int64, url string, secret string, event Event) (*int, error) {
+	body, jsonErr := json.Marshal(event)
+	if jsonErr != nil {
+		return nil, jsonErr
+	}
+
+	req, err := http.NewRequest("POST", url, bytes2.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().UnixMilli()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Lists-Event", event.Type)
+	req.Header.Set("X-Lists-Delivery", strconv.FormatInt(deliveryId, 10))
+	req.Header.Set("X-Lists-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Lists-Signature", signPayload(secret, timestamp, body))
+
+	resp, respErr := httpClient.Do(req)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &resp.StatusCode, fmt.Errorf("The subscriber responded with status %d.", resp.StatusCode)
+	}
+
+	return &resp.StatusCode, nil
+}
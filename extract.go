@@ -0,0 +1,238 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// extractFields are the stats that can be extracted from the response of a service's get stats URL.
+var extractFields = []string{"guild_count", "shard_count", "votes", "monthly_votes", "rank", "rating"}
+
+type pathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parsePath parses a JSONPath-like expression such as $.bot.stats[0]['server_count'] into its segments. The leading $
+// is optional, so plain dotted paths like bot.server_count are accepted as well.
+func parsePath(path string) ([]pathSegment, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	if rest != "" && rest[0] != '.' && rest[0] != '[' {
+		rest = "." + rest
+	}
+
+	var segments []pathSegment
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end == -1 {
+				end = len(rest) - 1
+			}
+
+			key := rest[1 : end+1]
+			if key == "" {
+				return nil, fmt.Errorf("The path '%s' has an empty key.", path)
+			}
+
+			segments = append(segments, pathSegment{key: key})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("The path '%s' has an unclosed bracket.", path)
+			}
+
+			inner := rest[1:end]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segments = append(segments, pathSegment{key: inner[1 : len(inner)-1]})
+			} else {
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("The path '%s' has an invalid index '%s'.", path, inner)
+				}
+
+				segments = append(segments, pathSegment{index: index, isIndex: true})
+			}
+
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("The path '%s' is invalid at '%s'.", path, rest)
+		}
+	}
+
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("The path '%s' doesn't select anything.", path)
+	}
+
+	return segments, nil
+}
+
+// lookupPath returns the value the path points to in the decoded JSON data. Negative indexes count from the end of
+// an array.
+func lookupPath(data interface{}, path string) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	current := data
+	at := "$"
+	for _, segment := range segments {
+		if segment.isIndex {
+			array, ok := current.([]interface{})
+			if !ok {
+				return nil, fmt.Errorf("expected an array at '%s' but found %s", at, describeJsonType(current))
+			}
+
+			index := segment.index
+			if index < 0 {
+				index += len(array)
+			}
+
+			if index < 0 || index >= len(array) {
+				return nil, fmt.Errorf("the index %d is out of range at '%s'", segment.index, at)
+			}
+
+			current = array[index]
+			at = fmt.Sprintf("%s[%d]", at, segment.index)
+			continue
+		}
+
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected an object at '%s' but found %s", at, describeJsonType(current))
+		}
+
+		value, exists := object[segment.key]
+		if !exists {
+			return nil, fmt.Errorf("the key '%s' doesn't exist at '%s'", segment.key, at)
+		}
+
+		current = value
+		at = at + "." + segment.key
+	}
+
+	return current, nil
+}
+
+func describeJsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	default:
+		return "a number"
+	}
+}
+
+// coerceFloat converts an extracted value to a float. Lists aren't consistent about how they return numbers, so
+// numeric strings like "52,000" are accepted as well.
+func coerceFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("the number %s is out of range", v)
+		}
+
+		return f, nil
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(v), ",", ""), 64)
+		if err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return 0, fmt.Errorf("the string '%s' isn't a number", v)
+		}
+
+		return f, nil
+	default:
+		return 0, fmt.Errorf("expected a number but found %s", describeJsonType(value))
+	}
+}
+
+// coerceInt converts an extracted value to an integer, rounding fractional numbers. Integers are parsed exactly
+// rather than through a float so that large counts aren't rounded.
+func coerceInt(value interface{}) (int64, error) {
+	var s string
+	switch v := value.(type) {
+	case json.Number:
+		s = v.String()
+	case string:
+		s = strings.ReplaceAll(strings.TrimSpace(v), ",", "")
+	}
+
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return i, nil
+	}
+
+	f, err := coerceFloat(value)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(math.Round(f)), nil
+}
+
+// lookupStat looks up the path of the stat in the response, describing what went wrong in an ExtractionError when
+// the path is invalid or doesn't exist.
+func lookupStat(service string, field string, path string, data interface{}) (interface{}, *ExtractionError) {
+	if _, err := parsePath(path); err != nil {
+		return nil, &ExtractionError{Service: service, Field: field, Path: path, Message: err.Error()}
+	}
+
+	value, err := lookupPath(data, path)
+	if err != nil {
+		return nil, newExtractionError(service, field, path, err)
+	}
+
+	return value, nil
+}
+
+func newExtractionError(service string, field string, path string, err error) *ExtractionError {
+	return &ExtractionError{
+		Service: service,
+		Field:   field,
+		Path:    path,
+		Message: fmt.Sprintf("Couldn't extract '%s' at '%s': %s.", field, path, err),
+	}
+}
+
+func extractInt(service string, field string, path string, data interface{}) (int64, *ExtractionError) {
+	value, extractErr := lookupStat(service, field, path, data)
+	if extractErr != nil {
+		return 0, extractErr
+	}
+
+	number, err := coerceInt(value)
+	if err != nil {
+		return 0, newExtractionError(service, field, path, err)
+	}
+
+	return number, nil
+}
+
+func extractFloat(service string, field string, path string, data interface{}) (float64, *ExtractionError) {
+	value, extractErr := lookupStat(service, field, path, data)
+	if extractErr != nil {
+		return 0, extractErr
+	}
+
+	number, err := coerceFloat(value)
+	if err != nil {
+		return 0, newExtractionError(service, field, path, err)
+	}
+
+	return number, nil
+}
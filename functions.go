@@ -3,6 +3,7 @@ package main
 import (
 	bytes2 "bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"github.com/go-playground/validator/v10"
@@ -14,14 +15,17 @@ import (
 	"github.com/gofiber/keyauth/v2"
 	"github.com/gofiber/swagger"
 	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
-	"github.com/joeycumines/go-dotnotation/dotnotation"
 	"github.com/pelletier/go-toml"
 	"github.com/pelletier/go-toml/query"
 	"io"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
@@ -29,44 +33,126 @@ import (
 var conn *pgxpool.Pool
 var config *toml.Tree
 
+const redactedValue = "[REDACTED]"
+
 func handleServer() {
+	// Behind a proxy the client's IP is read from the configured header, which is only trusted from the listed proxies
+	// when any are set, so that the rate limits and audit log see the client rather than the proxy.
+	trustedProxies := getConfigStringArray("api.proxy.trusted_proxies")
 	app := fiber.New(fiber.Config{
-		ErrorHandler: formErrorMessage,
+		ErrorHandler:            formErrorMessage,
+		ProxyHeader:             config.GetDefault("api.proxy.header", "").(string),
+		EnableTrustedProxyCheck: len(trustedProxies) > 0,
+		TrustedProxies:          trustedProxies,
+		EnableIPValidation:      true,
 	})
 
 	app.Use(logger.New(logger.Config{
 		Format:     config.Get("api.logger.format").(string),
 		TimeFormat: config.Get("api.logger.time_format").(string),
 		TimeZone:   config.Get("api.logger.timezone").(string),
+		Output:     &redactingWriter{writer: os.Stdout},
 	}))
 	app.Use(recover.New())
-	app.Use(cors.New(cors.Config{
+
+	// Every route outside of the public group, which has its own CORS config, shares the API's CORS config.
+	apiCors := cors.New(cors.Config{
 		AllowOrigins: config.GetArray("api.cors.allow_origins").(string),
 		AllowHeaders: config.GetArray("api.cors.allow_headers").(string),
-	}))
+	})
+
+	app.Use("/docs", apiCors)
 	app.Get("/docs/*", swagger.HandlerDefault)
 
+	rateLimits = newRateLimitStore()
+
 	api := app.Group("/api")
 
+	api.Use("/webhooks", apiCors)
+	api.Post("/webhooks/votes/:service", postVoteWebhookRoute)
+
+	if config.GetDefault("public.enabled", false).(bool) {
+		// The public routes are read-only and don't take an API key, so they can be read from any origin unless
+		// limited in the config.
+		public := api.Group("/public")
+		public.Use(cors.New(cors.Config{
+			AllowOrigins: config.GetDefault("public.cors.allow_origins", "*").(string),
+			AllowHeaders: config.GetDefault("public.cors.allow_headers", "Origin, Content-Type, Accept").(string),
+			AllowMethods: "GET,HEAD",
+		}))
+		public.Use(rateLimit("public"))
+
+		public.Get("/guilds", getPublicGuildCountRoute)
+		public.Get("/services", getPublicServicesRoute)
+		public.Get("/badges/servers", getServersBadgeRoute)
+		public.Get("/badges/votes/:service", getVotesBadgeRoute)
+		public.Get("/badges/growth", getGrowthSparklineRoute)
+	}
+
 	v1 := api.Group("/v1")
+	v1.Use(apiCors)
+	v1.Use(rateLimit("v1", rateLimitByIp))
 	v1.Use(keyauth.New(keyauth.Config{
 		KeyLookup:    config.Get("api.auth.header_key").(string),
 		ErrorHandler: formErrorMessage,
 		Validator:    validateAuthToken,
 	}))
 
-	v1.Post("/guilds", postGuildCountRoute)
+	v1.Use(rateLimit("v1", rateLimitByKey))
+
+	if config.GetDefault("audit.enabled", false).(bool) {
+		v1.Use(auditMiddleware)
+	}
+
+	v1.Post("/guilds", rateLimit("guilds"), postGuildCountRoute)
 	v1.Get("/guilds", getGuildCountRoute)
+	v1.Get("/guilds/stats", getGuildStatsRoute)
+
+	v1.Get("/milestones", getMilestonesRoute)
 
 	v1.Get("/services", getBotListServicesRoute)
 	v1.Get("/services/:service", getSingleBotListServiceRoute)
+	v1.Get("/services/:service/history", getServiceHistoryRoute)
+
+	v1.Get("/votes", getVotesRoute)
+	v1.Get("/votes/leaderboard", getVoteLeaderboardRoute)
+	v1.Get("/votes/users/:user", getUserVoteStatusRoute)
+	v1.Get("/votes/users/:user/streak", getUserVoteStreakRoute)
+
+	v1.Post("/reminders", postReminderSubscriptionRoute)
+	v1.Get("/reminders/:user", getReminderSubscriptionRoute)
+	v1.Delete("/reminders/:user", deleteReminderSubscriptionRoute)
+
+	v1.Post("/subscriptions", postSubscriptionRoute)
+	v1.Get("/subscriptions", getSubscriptionsRoute)
+	v1.Get("/subscriptions/:id", getSubscriptionRoute)
+	v1.Delete("/subscriptions/:id", deleteSubscriptionRoute)
+	v1.Get("/subscriptions/:id/deliveries", getDeliveriesRoute)
+	v1.Post("/deliveries/:id/redeliver", postRedeliveryRoute)
+	v1.Get("/events/stream", getEventStreamRoute)
+
+	admin := v1.Group("/admin", rateLimit("admin"))
+	admin.Get("/tokens", getTokensCheckRoute)
+	admin.Get("/services", getAdminServicesRoute)
+	admin.Post("/services/:service/enable", postServiceEnableRoute)
+	admin.Post("/services/:service/disable", postServiceDisableRoute)
+	admin.Post("/services/:service/pause", postServicePauseRoute)
+	admin.Delete("/services/:service/override", deleteServiceOverrideRoute)
+	admin.Get("/services/:service/audit", getServiceOverrideAuditRoute)
+	admin.Get("/audit", getAuditLogRoute)
+
+	startServiceDataRefresher()
+	startReminderScheduler()
+	startEventDeliverer()
+	startSnapshotter()
+	checkTokensOnStartup()
 
 	port := os.Getenv("API_PORT")
 	log.Fatal(app.Listen(fmt.Sprintf(":%s", port)))
 }
 
 func loadDatabase() {
-	dbpool, connErr := pgxpool.Connect(context.Background(), os.Getenv("POSTGRES_URL"))
+	dbpool, connErr := pgxpool.Connect(context.Background(), getSecret("POSTGRES_URL"))
 	if connErr != nil {
 		log.Fatal(connErr)
 	}
@@ -109,14 +195,17 @@ func formErrorMessage(ctx *fiber.Ctx, err error) error {
 	return ctx.Status(code).JSON(formJsonBody(
 		fiber.Map{
 			"code":    code,
-			"message": message,
+			"message": redactSecrets(message),
 		},
 		false,
 	))
 }
 
-func fetchStats(httpClient *http.Client, config BotListServiceConfig) (*BotListServiceResponse, error) {
-	token := getServiceToken(config.ShortName)
+func fetchStats(config BotListServiceConfig) (*BotListServiceResponse, error) {
+	httpClient, clientErr := getServiceClient(config.ShortName)
+	if clientErr != nil {
+		return nil, clientErr
+	}
 
 	req, err := http.NewRequest("GET", config.GetStatsUrl, nil)
 	if err != nil {
@@ -128,9 +217,9 @@ func fetchStats(httpClient *http.Client, config BotListServiceConfig) (*BotListS
 		}, nil
 	}
 
-	req.Header.Set("Authorization", token)
+	applyServiceAuth(req, config)
 
-	resp, respErr := httpClient.Do(req)
+	resp, respErr := doWithBreaker(httpClient, config.ShortName, req)
 	if respErr != nil {
 		return &BotListServiceResponse{
 			ShortName:  config.ShortName,
@@ -152,8 +241,11 @@ func fetchStats(httpClient *http.Client, config BotListServiceConfig) (*BotListS
 		}, nil
 	}
 
+	decoder := json.NewDecoder(bytes2.NewReader(body))
+	decoder.UseNumber()
+
 	var bodyData interface{}
-	bodyDataErr := json.Unmarshal(body, &bodyData)
+	bodyDataErr := decoder.Decode(&bodyData)
 	if bodyDataErr != nil {
 		return &BotListServiceResponse{
 			ShortName:  config.ShortName,
@@ -163,78 +255,219 @@ func fetchStats(httpClient *http.Client, config BotListServiceConfig) (*BotListS
 		}, nil
 	}
 
-	var BotListAccessor dotnotation.Accessor
-	guildCount, gcErr := BotListAccessor.Get(bodyData, config.Accessor)
-	if gcErr != nil {
-		return nil, gcErr
+	return extractServiceStats(config, bodyData), nil
+}
+
+// extractServiceStats pulls every configured stat out of the service's response. A stat that can't be extracted is
+// reported in the response's extraction errors, and the response is marked as errored if it was the guild count.
+func extractServiceStats(config BotListServiceConfig, data interface{}) *BotListServiceResponse {
+	response := &BotListServiceResponse{
+		ShortName: config.ShortName,
+		Url:       config.Url,
+	}
+
+	failed := func(extractErr *ExtractionError) bool {
+		if extractErr == nil {
+			return false
+		}
+
+		log.Printf("Failed to extract the stats of %s: %s", config.ShortName, extractErr.Message)
+		response.ExtractionErrors = append(response.ExtractionErrors, extractErr)
+		response.Error = response.Error || extractErr.Field == "guild_count"
+
+		return true
+	}
+
+	for _, field := range extractFields {
+		path, ok := config.Extract[field]
+		if !ok {
+			continue
+		}
+
+		if field == "rating" {
+			rating, extractErr := extractFloat(config.ShortName, field, path, data)
+			if !failed(extractErr) {
+				response.Rating = &rating
+			}
+
+			continue
+		}
+
+		value, extractErr := extractInt(config.ShortName, field, path, data)
+		if failed(extractErr) {
+			continue
+		}
+
+		switch field {
+		case "guild_count":
+			response.GuildCount = value
+		case "shard_count":
+			response.ShardCount = &value
+		case "votes":
+			response.Votes = &value
+		case "monthly_votes":
+			response.MonthlyVotes = &value
+		case "rank":
+			response.Rank = &value
+		}
+	}
+
+	return response
+}
+
+// buildStatsRequests builds the requests posting the stats to the service. Services posting per shard receive one
+// request for each shard in the breakdown, while every other service receives a single request with the totals.
+func buildStatsRequests(service BotListServiceConfig, guild GuildCountRequestBody) ([]*http.Request, error) {
+	if service.ShardPosting != "per_shard" || len(guild.ShardStats) == 0 {
+		req, err := buildStatsRequest(service, buildStatsPayload(service, guild))
+		if err != nil {
+			return nil, err
+		}
+
+		return []*http.Request{req}, nil
+	}
+
+	var requests []*http.Request
+	for _, shard := range guild.ShardStats {
+		req, err := buildStatsRequest(service, buildShardStatsPayload(service, guild, shard))
+		if err != nil {
+			return nil, err
+		}
+
+		requests = append(requests, req)
+	}
+
+	return requests, nil
+}
+
+func buildStatsRequest(service BotListServiceConfig, data fiber.Map) (*http.Request, error) {
+	body, contentType, encodeErr := encodeStatsPayload(service, data)
+	if encodeErr != nil {
+		return nil, encodeErr
+	}
+
+	req, err := http.NewRequest(service.Method, service.PostStatsUrl, bytes2.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+
+	applyServiceAuth(req, service)
+	req.Header.Set("Content-Type", contentType)
+
+	return req, nil
+}
+
+// encodeStatsPayload encodes the payload in the body encoding the service expects, returning the content type to send
+// it with. Form encoded arrays repeat their field once per value.
+func encodeStatsPayload(service BotListServiceConfig, data fiber.Map) ([]byte, string, error) {
+	if service.Encoding != "form" {
+		jsonData, err := json.Marshal(data)
+		return jsonData, fiber.MIMEApplicationJSON, err
+	}
+
+	form := url.Values{}
+	for field, value := range data {
+		if values, ok := value.([]int64); ok {
+			for _, v := range values {
+				form.Add(field, fmt.Sprint(v))
+			}
+			continue
+		}
+
+		form.Set(field, fmt.Sprint(value))
 	}
 
-	return &BotListServiceResponse{
-		ShortName:  config.ShortName,
-		Url:        config.Url,
-		GuildCount: int64(guildCount.(float64)),
-	}, nil
+	return []byte(form.Encode()), fiber.MIMEApplicationForm, nil
 }
 
-func postStatsToBotList(httpClient *http.Client, service BotListServiceConfig, guildCount int64, shardCount int64) error {
+// applyServiceAuth adds the service's token to the request where the service expects it, either in a header or in the
+// query string, preceded by the configured prefix such as Bearer.
+func applyServiceAuth(req *http.Request, service BotListServiceConfig) {
 	token := getServiceToken(service.ShortName)
+	if service.AuthPrefix != "" {
+		token = service.AuthPrefix + " " + token
+	}
 
-	var data = fiber.Map{service.Key: guildCount}
-	if service.ShortName == "botsgg" {
-		withShardCount := &data
-		*withShardCount = fiber.Map{service.Key: guildCount, "shardCount": shardCount}
+	switch service.AuthPlacement {
+	case "none":
+		return
+	case "query":
+		query := req.URL.Query()
+		query.Set(service.AuthName, token)
+		req.URL.RawQuery = query.Encode()
+	default:
+		req.Header.Set(service.AuthName, token)
 	}
+}
 
-	jsonData, jsonErr := json.Marshal(data)
-	if jsonErr != nil {
-		return jsonErr
+func postStatsToBotList(service BotListServiceConfig, guild GuildCountRequestBody) error {
+	httpClient, clientErr := getServiceClient(service.ShortName)
+	if clientErr != nil {
+		return &BotListError{Service: service.ShortName, Message: redactSecrets(clientErr.Error())}
 	}
 
-	req, err := http.NewRequest("POST", service.PostStatsUrl, bytes2.NewBuffer(jsonData))
+	requests, err := buildStatsRequests(service, guild)
 	if err != nil {
-		return err
+		return &BotListError{Service: service.ShortName, Message: redactSecrets(err.Error())}
+	}
+
+	for _, req := range requests {
+		if postErr := sendStatsRequest(httpClient, service, req); postErr != nil {
+			return postErr
+		}
 	}
 
-	req.Header.Set("Authorization", token)
-	req.Header.Set("Content-Type", "application/json")
+	return nil
+}
 
-	resp, respErr := httpClient.Do(req)
+func sendStatsRequest(httpClient *http.Client, service BotListServiceConfig, req *http.Request) error {
+	resp, respErr := doWithBreaker(httpClient, service.ShortName, req)
 	if respErr != nil {
-		return respErr
+		// The error contains the URL, which may contain the token.
+		return &BotListError{Service: service.ShortName, Message: redactSecrets(respErr.Error())}
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &BotListError{
+			Service:    service.ShortName,
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("The bot list responded with status %d.", resp.StatusCode),
+		}
 	}
 
 	var res fiber.Map
 	decErr := json.NewDecoder(resp.Body).Decode(&res)
 	if decErr != nil {
-		return decErr
+		return &BotListError{Service: service.ShortName, StatusCode: resp.StatusCode, Message: decErr.Error()}
 	}
 
 	return nil
 }
 
-func postStatsToBotLists(guildCount int64, shardCount int64) []error {
+func postStatsToBotLists(guild GuildCountRequestBody, configs []string) []error {
 	wg := sync.WaitGroup{}
 	locker := sync.Mutex{}
 
 	var errors []error
-	configs := getActiveServices()
-
-	client := &http.Client{Timeout: time.Second * 30}
 
 	for _, config := range configs {
 		wg.Add(1)
 		go func(c BotListServiceConfig) {
 			defer wg.Done()
 
-			err := postStatsToBotList(client, c, guildCount, shardCount)
-			if err != nil {
+			err := postStatsToBotList(c, guild)
+			recordPostResult(c.ShortName, err)
+			if err == nil {
+				emitEventAsync("service.posted", ServicePost{Service: c.ShortName, Guilds: guild.Guilds, Shards: guild.Shards})
+			} else {
+				locker.Lock()
+				defer locker.Unlock()
+
 				errors = append(errors, err)
-				return
 			}
 
-			locker.Lock()
-			defer locker.Unlock()
-
 			return
 		}(getServiceConfig(config))
 	}
@@ -244,6 +477,182 @@ func postStatsToBotLists(guildCount int64, shardCount int64) []error {
 	return errors
 }
 
+// previewStatsRequest builds the exact request that would be sent to the service without sending it. Problems that
+// would stop the request from succeeding, such as invalid config or a missing token, are reported as errors.
+func previewStatsRequest(service string, guild GuildCountRequestBody) ServiceRequestPreview {
+	preview := ServiceRequestPreview{Service: service}
+
+	preview.Errors = validateServiceConfig(service)
+	if len(preview.Errors) > 0 {
+		return preview
+	}
+
+	serviceConfig := getServiceConfig(service)
+	if getServiceToken(service) == "" {
+		preview.Errors = append(preview.Errors, fmt.Sprintf("The token is missing, neither SERVICES_%[1]s_TOKEN nor SERVICES_%[1]s_TOKEN_FILE is set.", utils.ToUpper(service)))
+	}
+
+	stats := buildStatsPayload(serviceConfig, guild)
+	if serviceConfig.ShardPosting == "per_shard" && len(guild.ShardStats) > 0 {
+		stats = buildShardStatsPayload(serviceConfig, guild, guild.ShardStats[0])
+	}
+
+	for stat, field := range serviceConfig.Fields {
+		if _, ok := stats[field]; !ok {
+			preview.Warnings = append(preview.Warnings, fmt.Sprintf("The stat '%s' is mapped to '%s' but was not provided.", stat, field))
+		}
+	}
+
+	requests, err := buildStatsRequests(serviceConfig, guild)
+	if err != nil {
+		preview.Errors = append(preview.Errors, redactSecrets(err.Error()))
+		return preview
+	}
+
+	if len(requests) > 1 {
+		preview.Warnings = append(preview.Warnings, fmt.Sprintf("The stats are posted per shard in %d requests, only the first is shown.", len(requests)))
+	}
+
+	sort.Strings(preview.Warnings)
+
+	req := requests[0]
+
+	body, bodyErr := io.ReadAll(req.Body)
+	if bodyErr != nil {
+		preview.Errors = append(preview.Errors, bodyErr.Error())
+		return preview
+	}
+
+	if serviceConfig.AuthPlacement == "query" {
+		query := req.URL.Query()
+		query.Set(serviceConfig.AuthName, redactedValue)
+		req.URL.RawQuery = query.Encode()
+	}
+
+	if serviceConfig.Encoding == "form" {
+		body, _ = json.Marshal(string(body))
+	}
+
+	// Tokens the service's config places elsewhere than its auth, such as in the URL, are redacted too.
+	preview.Method = req.Method
+	preview.Url = redactSecrets(req.URL.String())
+	preview.Body = json.RawMessage(redactSecrets(string(body)))
+	preview.Headers = make(map[string]string)
+	for name := range req.Header {
+		preview.Headers[name] = redactSecrets(req.Header.Get(name))
+	}
+
+	if serviceConfig.AuthPlacement == "header" {
+		preview.Headers[http.CanonicalHeaderKey(serviceConfig.AuthName)] = redactedValue
+	}
+
+	preview.Valid = len(preview.Errors) == 0
+
+	return preview
+}
+
+func previewStatsRequests(guild GuildCountRequestBody, services []string) []ServiceRequestPreview {
+	var previews []ServiceRequestPreview
+	for _, service := range services {
+		previews = append(previews, previewStatsRequest(service, guild))
+	}
+
+	return previews
+}
+
+// validateServiceConfig checks that every required key is set for the service with the expected type and that its URLs
+// are absolute, returning a message for each problem found.
+func validateServiceConfig(service string) []string {
+	var problems []string
+
+	for _, key := range []string{"short_name", "long_name", "url", "get_stats_url", "post_stats_url"} {
+		path := fmt.Sprintf("services.%s.%s", service, key)
+		value, ok := config.Get(path).(string)
+		if !ok {
+			problems = append(problems, fmt.Sprintf("The config key '%s' must be a string.", path))
+			continue
+		}
+
+		if strings.HasSuffix(key, "url") {
+			parsed, err := url.Parse(value)
+			if err != nil || !parsed.IsAbs() || parsed.Host == "" {
+				problems = append(problems, fmt.Sprintf("The config key '%s' must be an absolute URL.", path))
+			}
+		}
+	}
+
+	if _, err := getServiceClient(service); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if _, ok := config.Get(fmt.Sprintf("services.%s.enabled", service)).(bool); !ok {
+		problems = append(problems, fmt.Sprintf("The config key 'services.%s.enabled' must be a boolean.", service))
+	}
+
+	for _, option := range []struct {
+		key    string
+		values []string
+	}{
+		{"method", []string{"POST", "PUT", "PATCH"}},
+		{"encoding", []string{"json", "form"}},
+		{"auth.placement", []string{"header", "query", "none"}},
+		{"shard_posting", []string{"array", "per_shard"}},
+	} {
+		path := fmt.Sprintf("services.%s.%s", service, option.key)
+		if !config.Has(path) {
+			continue
+		}
+
+		value, _ := config.Get(path).(string)
+		if option.key == "method" {
+			value = utils.ToUpper(value)
+		}
+
+		if !containsString(option.values, value) {
+			problems = append(problems, fmt.Sprintf("The config key '%s' must be one of %s.", path, strings.Join(option.values, ", ")))
+		}
+	}
+
+	fields, ok := config.Get(fmt.Sprintf("services.%s.fields", service)).(*toml.Tree)
+	if ok {
+		for stat, field := range fields.ToMap() {
+			if _, isString := field.(string); !isString {
+				problems = append(problems, fmt.Sprintf("The field mapped to the stat '%s' must be a string.", stat))
+			}
+		}
+	} else if _, hasKey := config.Get(fmt.Sprintf("services.%s.key", service)).(string); !hasKey {
+		problems = append(problems, fmt.Sprintf("Either 'services.%s.fields' or 'services.%s.key' must be set.", service, service))
+	}
+
+	extract, ok := config.Get(fmt.Sprintf("services.%s.extract", service)).(*toml.Tree)
+	if ok {
+		for stat, path := range extract.ToMap() {
+			if !containsString(extractFields, stat) {
+				problems = append(problems, fmt.Sprintf("The stat '%s' can't be extracted, it must be one of %s.", stat, strings.Join(extractFields, ", ")))
+				continue
+			}
+
+			pathString, isString := path.(string)
+			if !isString {
+				problems = append(problems, fmt.Sprintf("The path the stat '%s' is extracted from must be a string.", stat))
+				continue
+			}
+
+			if _, err := parsePath(pathString); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+
+		if _, hasGuildCount := extract.Get("guild_count").(string); !hasGuildCount {
+			problems = append(problems, fmt.Sprintf("The config key 'services.%s.extract.guild_count' must be set.", service))
+		}
+	} else if _, hasAccessor := config.Get(fmt.Sprintf("services.%s.accessor", service)).(string); !hasAccessor {
+		problems = append(problems, fmt.Sprintf("Either 'services.%s.extract' or 'services.%s.accessor' must be set.", service, service))
+	}
+
+	return problems
+}
+
 func fetchBotListServiceData() ([]BotListServiceResponse, []error) {
 	wg := sync.WaitGroup{}
 	locker := sync.Mutex{}
@@ -252,22 +661,21 @@ func fetchBotListServiceData() ([]BotListServiceResponse, []error) {
 	var errors []error
 	configs := getActiveServices()
 
-	client := &http.Client{Timeout: time.Second * 30}
-
 	for _, config := range configs {
 		wg.Add(1)
 		go func(c BotListServiceConfig) {
 			defer wg.Done()
 
-			data, err := fetchStats(client, c)
+			data, err := fetchStats(c)
+
+			locker.Lock()
+			defer locker.Unlock()
+
 			if err != nil {
 				errors = append(errors, err)
 				return
 			}
 
-			locker.Lock()
-			defer locker.Unlock()
-
 			responses = append(responses, *data)
 
 			return
@@ -281,15 +689,188 @@ func fetchBotListServiceData() ([]BotListServiceResponse, []error) {
 
 func getServiceConfig(service string) BotListServiceConfig {
 	return BotListServiceConfig{
-		ShortName:    config.Get(fmt.Sprintf("services.%s.short_name", service)).(string),
-		LongName:     config.Get(fmt.Sprintf("services.%s.long_name", service)).(string),
-		Url:          config.Get(fmt.Sprintf("services.%s.url", service)).(string),
-		GetStatsUrl:  config.Get(fmt.Sprintf("services.%s.get_stats_url", service)).(string),
-		PostStatsUrl: config.Get(fmt.Sprintf("services.%s.post_stats_url", service)).(string),
-		Accessor:     config.Get(fmt.Sprintf("services.%s.accessor", service)).(string),
-		Key:          config.Get(fmt.Sprintf("services.%s.key", service)).(string),
-		Enabled:      config.Get(fmt.Sprintf("services.%s.enabled", service)).(bool),
+		ShortName:     config.Get(fmt.Sprintf("services.%s.short_name", service)).(string),
+		LongName:      config.Get(fmt.Sprintf("services.%s.long_name", service)).(string),
+		Url:           config.Get(fmt.Sprintf("services.%s.url", service)).(string),
+		GetStatsUrl:   config.Get(fmt.Sprintf("services.%s.get_stats_url", service)).(string),
+		PostStatsUrl:  config.Get(fmt.Sprintf("services.%s.post_stats_url", service)).(string),
+		Extract:       getServiceExtract(service),
+		Fields:        getServiceFields(service),
+		Enabled:       config.Get(fmt.Sprintf("services.%s.enabled", service)).(bool),
+		Method:        utils.ToUpper(config.GetDefault(fmt.Sprintf("services.%s.method", service), "POST").(string)),
+		Encoding:      config.GetDefault(fmt.Sprintf("services.%s.encoding", service), "json").(string),
+		AuthPlacement: config.GetDefault(fmt.Sprintf("services.%s.auth.placement", service), "header").(string),
+		AuthName:      getServiceAuthName(service),
+		AuthPrefix:    config.GetDefault(fmt.Sprintf("services.%s.auth.prefix", service), "").(string),
+		ShardPosting:  config.GetDefault(fmt.Sprintf("services.%s.shard_posting", service), "array").(string),
+	}
+}
+
+// getServiceAuthName returns the header or query parameter the service's token is sent in, defaulting to the
+// Authorization header or the token query parameter.
+func getServiceAuthName(service string) string {
+	if name, ok := config.Get(fmt.Sprintf("services.%s.auth.name", service)).(string); ok {
+		return name
+	}
+
+	if config.Get(fmt.Sprintf("services.%s.auth.placement", service)) == "query" {
+		return "token"
+	}
+
+	return "Authorization"
+}
+
+// getServiceFields returns which stats are sent to the service and the payload field names they are sent as. Services
+// without a fields table fall back to sending only the guild count under their configured key.
+func getServiceFields(service string) map[string]string {
+	fields := make(map[string]string)
+
+	tree, ok := config.Get(fmt.Sprintf("services.%s.fields", service)).(*toml.Tree)
+	if !ok {
+		fields["guild_count"] = config.Get(fmt.Sprintf("services.%s.key", service)).(string)
+		return fields
+	}
+
+	for stat, field := range tree.ToMap() {
+		fields[stat] = field.(string)
+	}
+
+	return fields
+}
+
+// getServiceExtract returns the paths each stat is extracted from in the service's stats response. Services without an
+// extract table fall back to extracting only the guild count at their configured accessor.
+func getServiceExtract(service string) map[string]string {
+	extract := make(map[string]string)
+
+	tree, ok := config.Get(fmt.Sprintf("services.%s.extract", service)).(*toml.Tree)
+	if !ok {
+		extract["guild_count"] = config.Get(fmt.Sprintf("services.%s.accessor", service)).(string)
+		return extract
+	}
+
+	for stat, path := range tree.ToMap() {
+		extract[stat] = path.(string)
+	}
+
+	return extract
+}
+
+// buildStatsPayload maps the posted stats onto the payload fields the service expects. The built-in guild_count,
+// shard_count and shards stats are always available, along with anything passed in the request's stats object.
+func buildStatsPayload(service BotListServiceConfig, guild GuildCountRequestBody) fiber.Map {
+	return mapStatsPayload(service, collectStats(guild))
+}
+
+// buildShardStatsPayload maps the stats of a single shard onto the payload fields the service expects, sending the
+// shard's guild count as the guild_count stat along with its shard_id.
+func buildShardStatsPayload(service BotListServiceConfig, guild GuildCountRequestBody, shard ShardGuildCount) fiber.Map {
+	stats := collectStats(guild)
+	delete(stats, "shards")
+
+	stats["guild_count"] = shard.Guilds
+	stats["shard_id"] = shard.ShardId
+
+	return mapStatsPayload(service, stats)
+}
+
+func collectStats(guild GuildCountRequestBody) map[string]interface{} {
+	stats := make(map[string]interface{})
+	for name, value := range guild.Stats {
+		stats[name] = value
+	}
+
+	stats["guild_count"] = guild.Guilds
+	stats["shard_count"] = guild.Shards
+	if len(guild.ShardStats) > 0 {
+		stats["shards"] = buildShardArray(guild.ShardStats)
+	}
+
+	return stats
+}
+
+func mapStatsPayload(service BotListServiceConfig, stats map[string]interface{}) fiber.Map {
+	data := fiber.Map{}
+	for stat, field := range service.Fields {
+		if value, ok := stats[stat]; ok {
+			data[field] = value
+		}
+	}
+
+	return data
+}
+
+// getConfigDuration parses the duration string set at the config key, falling back to the given duration when the key
+// isn't set or can't be parsed.
+func getConfigDuration(key string, fallback time.Duration) time.Duration {
+	value, ok := config.Get(key).(string)
+	if !ok {
+		return fallback
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Printf("The config key '%s' is not a valid duration: %s", key, err)
+		return fallback
+	}
+
+	return duration
+}
+
+// getConfigFloat returns the number set at the config key, accepting both integers and floats, or the fallback when the
+// key isn't set.
+func getConfigFloat(key string, fallback float64) float64 {
+	switch value := config.Get(key).(type) {
+	case int64:
+		return float64(value)
+	case float64:
+		return value
+	default:
+		return fallback
+	}
+}
+
+// getConfigIntArray returns the integers set in the array at the config key, ignoring anything that isn't an integer.
+func getConfigIntArray(key string) []int64 {
+	var values []int64
+
+	array, _ := config.Get(key).([]interface{})
+	for _, value := range array {
+		if i, ok := value.(int64); ok {
+			values = append(values, i)
+		}
+	}
+
+	return values
+}
+
+// getConfigStringArray returns the strings set in the array at the config key, ignoring anything that isn't a string.
+func getConfigStringArray(key string) []string {
+	var values []string
+
+	array, _ := config.Get(key).([]interface{})
+	for _, value := range array {
+		if s, ok := value.(string); ok {
+			values = append(values, s)
+		}
+	}
+
+	return values
+}
+
+func setPublicCacheControl(ctx *fiber.Ctx) {
+	maxAge := getConfigDuration("public.cache_ttl", time.Minute)
+	ctx.Set(fiber.HeaderCacheControl, fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
 	}
+
+	return false
 }
 
 func getVersion() string {
@@ -297,7 +878,7 @@ func getVersion() string {
 }
 
 func getServiceToken(service string) string {
-	return os.Getenv(fmt.Sprintf("SERVICES_%s_TOKEN", utils.ToUpper(service)))
+	return getSecret(fmt.Sprintf("SERVICES_%s_TOKEN", utils.ToUpper(service)))
 }
 
 func execQuery(query string, args ...interface{}) (pgconn.CommandTag, error) {
@@ -308,10 +889,85 @@ func queryRow(query string, args ...interface{}) error {
 	return conn.QueryRow(context.Background(), query).Scan(args...)
 }
 
-func validateGuildCount(guild GuildCountRequestBody) []*ErrorResponse {
+func queryRows(query string, args ...interface{}) (pgx.Rows, error) {
+	return conn.Query(context.Background(), query, args...)
+}
+
+func insertGuildCount(guild GuildCountRequestBody) (int64, error) {
+	var id int64
+
+	err := conn.BeginFunc(context.Background(), func(tx pgx.Tx) error {
+		var stats interface{}
+		if len(guild.Stats) > 0 {
+			stats = guild.Stats
+		}
+
+		query := "insert into guildcount(guild_count, shard_count, stats) values ($1, $2, $3) returning id"
+		err := tx.QueryRow(context.Background(), query, guild.Guilds, guild.Shards, stats).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		for _, shard := range guild.ShardStats {
+			shardQuery := "insert into shardcount(guildcount_id, shard_id, guild_count) values ($1, $2, $3)"
+			_, execErr := tx.Exec(context.Background(), shardQuery, id, shard.ShardId, shard.Guilds)
+			if execErr != nil {
+				return execErr
+			}
+		}
+
+		return nil
+	})
+
+	return id, err
+}
+
+func getShardStats(guildCountId int64) ([]ShardGuildCount, error) {
+	var shards []ShardGuildCount
+
+	query := "select shard_id, guild_count from shardcount where guildcount_id = $1 order by shard_id"
+	rows, err := queryRows(query, guildCountId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var shard ShardGuildCount
+		if scanErr := rows.Scan(&shard.ShardId, &shard.Guilds); scanErr != nil {
+			return nil, scanErr
+		}
+
+		shards = append(shards, shard)
+	}
+
+	return shards, rows.Err()
+}
+
+// buildShardArray converts the per-shard breakdown into an array indexed by shard id, which is the format bot lists
+// such as top.gg expect. Shards missing from the breakdown are reported as 0. The shard ids are below the shard count,
+// which is capped when the request is validated, so the array can't grow unbounded.
+func buildShardArray(shards []ShardGuildCount) []int64 {
+	var highest int64 = -1
+	for _, shard := range shards {
+		if shard.ShardId > highest {
+			highest = shard.ShardId
+		}
+	}
+
+	counts := make([]int64, highest+1)
+	for _, shard := range shards {
+		counts[shard.ShardId] = shard.Guilds
+	}
+
+	return counts
+}
+
+func validateStruct(data interface{}) []*ErrorResponse {
 	var errors []*ErrorResponse
 	validate := validator.New()
-	err := validate.Struct(guild)
+	err := validate.Struct(data)
 	if err != nil {
 		for _, err := range err.(validator.ValidationErrors) {
 			var element ErrorResponse
@@ -325,9 +981,53 @@ func validateGuildCount(guild GuildCountRequestBody) []*ErrorResponse {
 	return errors
 }
 
+func validateGuildCount(guild GuildCountRequestBody) []*ErrorResponse {
+	errors := validateStruct(guild)
+
+	if guild.Services != nil {
+		for i, name := range guild.Services.Include {
+			if resolveServiceNames(name) == nil {
+				errors = append(errors, &ErrorResponse{
+					FailedField: fmt.Sprintf("GuildCountRequestBody.Services.Include[%d]", i),
+					Tag:         "service",
+					Value:       name,
+				})
+			}
+		}
+
+		for i, name := range guild.Services.Exclude {
+			if resolveServiceNames(name) == nil {
+				errors = append(errors, &ErrorResponse{
+					FailedField: fmt.Sprintf("GuildCountRequestBody.Services.Exclude[%d]", i),
+					Tag:         "service",
+					Value:       name,
+				})
+			}
+		}
+	}
+
+	for i, shard := range guild.ShardStats {
+		if shard.ShardId >= guild.Shards {
+			errors = append(errors, &ErrorResponse{
+				FailedField: fmt.Sprintf("GuildCountRequestBody.ShardStats[%d].ShardId", i),
+				Tag:         "ltfield",
+				Value:       "Shards",
+			})
+		}
+	}
+
+	return errors
+}
+
 func handleBotListErrors(ctx *fiber.Ctx, errors []error) error {
 	var data []interface{}
 	for _, err := range errors {
+		if botListErr, ok := err.(*BotListError); ok {
+			redacted := *botListErr
+			redacted.Message = redactSecrets(redacted.Message)
+			err = &redacted
+		}
+
 		data = append(data, err)
 	}
 
@@ -335,24 +1035,57 @@ func handleBotListErrors(ctx *fiber.Ctx, errors []error) error {
 	return ctx.JSON(formJsonBody(data, false))
 }
 
-func validateAuthToken(_ *fiber.Ctx, token string) (bool, error) {
-	tk := os.Getenv("API_TOKEN")
+// getApiKeys returns the API keys by their name. Named keys are set in API_KEYS as comma separated name:key pairs,
+// while API_TOKEN is accepted as the key named default.
+func getApiKeys() map[string]string {
+	keys := make(map[string]string)
+	if token := getSecret("API_TOKEN"); token != "" {
+		keys["default"] = token
+	}
+
+	for _, pair := range strings.Split(getSecret("API_KEYS"), ",") {
+		name, key, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if ok && name != "" && key != "" {
+			keys[name] = key
+		}
+	}
+
+	return keys
+}
 
-	if token != tk {
-		return false, nil
+// validateAuthToken accepts any of the API keys, storing the name of the key used so that requests can be attributed
+// to it.
+func validateAuthToken(ctx *fiber.Ctx, token string) (bool, error) {
+	for name, key := range getApiKeys() {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(key)) == 1 {
+			ctx.Locals("api_key", name)
+			return true, nil
+		}
 	}
 
-	return true, nil
+	return false, nil
+}
+
+// getApiKeyName returns the name of the API key the request was authenticated with.
+func getApiKeyName(ctx *fiber.Ctx) string {
+	name, _ := ctx.Locals("api_key").(string)
+
+	return name
 }
 
+// getActiveServices returns the services enabled in the config, unless they were disabled or paused through the admin
+// routes, along with services disabled in the config that were enabled through them.
 func getActiveServices() []string {
 	var services []string
 
+	overrides := serviceOverrides.get()
+	now := time.Now()
+
 	q, _ := query.Compile("$.services[?(active)].short_name")
 
 	q.SetFilter("active", func(node interface{}) bool {
 		if tree, ok := node.(*toml.Tree); ok {
-			return tree.Get("enabled").(bool) == true
+			return isServiceActive(tree.Get("short_name").(string), tree.Get("enabled").(bool), overrides, now)
 		}
 		return false
 	})
@@ -365,3 +1098,84 @@ func getActiveServices() []string {
 
 	return services
 }
+
+// isServiceName returns whether the name is a service defined in the config. Keys nested in a service's config, such
+// as "topgg.votes", aren't services.
+func isServiceName(name string) bool {
+	if name == "" || strings.Contains(name, ".") {
+		return false
+	}
+
+	tree, ok := config.Get(fmt.Sprintf("services.%s", name)).(*toml.Tree)
+
+	return ok && tree.Has("short_name")
+}
+
+// resolveServiceNames expands a name from a service selection into service short names. The name may either be a
+// service itself or a group defined in the config. Unknown names and groups with members that aren't service names
+// resolve to nil, so that they are rejected when validating the selection.
+func resolveServiceNames(name string) []string {
+	if isServiceName(name) {
+		return []string{name}
+	}
+
+	if strings.Contains(name, ".") {
+		return nil
+	}
+
+	members, ok := config.Get(fmt.Sprintf("groups.%s", name)).([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var services []string
+	for _, member := range members {
+		service, isString := member.(string)
+		if !isString || !isServiceName(service) {
+			log.Printf("The config key 'groups.%s' must only contain service names, found '%v'.", name, member)
+			return nil
+		}
+
+		services = append(services, service)
+	}
+
+	return services
+}
+
+// selectServices returns the active services targeted by the selection. Without a selection, or with an empty include
+// list, every active service is targeted before exclusions are applied.
+func selectServices(selection *ServiceSelection) []string {
+	activeServices := getActiveServices()
+	if selection == nil {
+		return activeServices
+	}
+
+	included := make(map[string]bool)
+	for _, name := range selection.Include {
+		for _, service := range resolveServiceNames(name) {
+			included[service] = true
+		}
+	}
+
+	excluded := make(map[string]bool)
+	for _, name := range selection.Exclude {
+		for _, service := range resolveServiceNames(name) {
+			excluded[service] = true
+		}
+	}
+
+	var services []string
+	for _, service := range activeServices {
+		if len(included) > 0 && !included[service] {
+			continue
+		}
+
+		if excluded[service] {
+			continue
+		}
+
+		services = append(services, service)
+	}
+
+	return services
+}
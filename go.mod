@@ -8,7 +8,6 @@ require (
 	github.com/gofiber/keyauth/v2 v2.1.30
 	github.com/jackc/pgconn v1.13.0
 	github.com/jackc/pgx/v4 v4.17.2
-	github.com/joeycumines/go-dotnotation v0.0.0-20180131115956-2d3612e36c5d
 	github.com/joho/godotenv v1.4.0
 	github.com/pelletier/go-toml v1.9.5
 	github.com/swaggo/swag v1.8.9
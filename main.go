@@ -6,6 +6,7 @@ import (
 	_ "github.com/suggestionsbot/lists/docs"
 	"log"
 	"os"
+	"strings"
 	"time"
 )
 
@@ -14,7 +15,8 @@ func init() {
 		log.Fatal("Error loading .env file")
 	}
 
-	loadDatabase()
+	log.SetOutput(&redactingWriter{writer: os.Stderr})
+
 	loadConfig()
 }
 
@@ -33,6 +35,18 @@ func init() {
 //	@tag.name			General
 //	@tag.description	All routes for the service.
 
+//	@tag.name			Votes
+//	@tag.description	Routes for receiving and querying votes from bot lists.
+
+//	@tag.name			Public
+//	@tag.description	Unauthenticated read-only routes serving cached data for the website.
+
+//	@tag.name			Events
+//	@tag.description	Routes for managing subscriptions to events delivered as webhooks.
+
+//	@tag.name			Admin
+//	@tag.description	Routes for operating the service.
+
 // @securityDefinitions	APIKeyHeader
 // @in						header
 //
@@ -46,5 +60,21 @@ func main() {
 	message := fmt.Sprintf("Lists %s - Copyright (c) %d Anthony Collier", version, year)
 	fmt.Println(message)
 
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(os.Args[1:]))
+	}
+
+	loadDatabase()
 	handleServer()
 }
+
+// runCommand runs the command passed on the command line instead of starting the server, returning its exit code.
+func runCommand(args []string) int {
+	switch strings.Join(args, " ") {
+	case "tokens check":
+		return runTokensCheck()
+	default:
+		fmt.Printf("Unknown command '%s'. Available commands: tokens check\n", strings.Join(args, " "))
+		return 2
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS shardcount;
\ No newline at end of file
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS shardcount(
+    id serial primary key,
+    guildcount_id integer references guildcount(id) on delete cascade,
+    shard_id integer,
+    guild_count integer,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+)
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+alter table guildcount
+    drop column stats;
+
+COMMIT;
\ No newline at end of file
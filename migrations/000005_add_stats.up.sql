@@ -0,0 +1,6 @@
+BEGIN;
+
+alter table guildcount
+    add column stats jsonb;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS votes;
\ No newline at end of file
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS votes(
+    id serial primary key,
+    service varchar(32) not null,
+    user_id varchar(32) not null,
+    is_weekend boolean not null default false,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+create index if not exists votes_user_id_service_created_at_idx
+    on votes (user_id, service, created_at desc);
+
+create index if not exists votes_created_at_idx
+    on votes (created_at desc);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS vote_reminders;
+DROP TABLE IF EXISTS reminder_subscriptions;
+
+COMMIT;
\ No newline at end of file
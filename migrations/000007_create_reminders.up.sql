@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS reminder_subscriptions(
+    user_id varchar(32) primary key,
+    services text[],
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+CREATE TABLE IF NOT EXISTS vote_reminders(
+    id serial primary key,
+    user_id varchar(32) not null,
+    service varchar(32) not null,
+    vote_id integer references votes(id) on delete cascade,
+    remind_at timestamp without time zone not null,
+    delivered_at timestamp without time zone,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+create index if not exists vote_reminders_pending_idx
+    on vote_reminders (remind_at) where delivered_at is null;
+
+COMMIT;
\ No newline at end of file
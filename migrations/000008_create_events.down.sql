@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS event_deliveries;
+DROP TABLE IF EXISTS subscriptions;
+DROP TABLE IF EXISTS events;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,38 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS events(
+    id bigserial primary key,
+    type varchar(64) not null,
+    payload jsonb,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+CREATE TABLE IF NOT EXISTS subscriptions(
+    id serial primary key,
+    url text not null,
+    secret text not null,
+    events text[],
+    enabled boolean not null default true,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+CREATE TABLE IF NOT EXISTS event_deliveries(
+    id bigserial primary key,
+    subscription_id integer not null references subscriptions(id) on delete cascade,
+    event_id bigint not null references events(id) on delete cascade,
+    status varchar(16) not null default 'pending',
+    attempts integer not null default 0,
+    response_code integer,
+    last_error text,
+    next_attempt_at timestamp without time zone not null default (now() at time zone ('utc')),
+    delivered_at timestamp without time zone,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+create index if not exists event_deliveries_pending_idx
+    on event_deliveries (next_attempt_at) where status = 'pending';
+
+create index if not exists event_deliveries_subscription_id_idx
+    on event_deliveries (subscription_id, created_at desc);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS milestones;
\ No newline at end of file
@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS milestones(
+    id serial primary key,
+    milestone integer unique not null,
+    guild_count integer not null,
+    previous_guild_count integer not null,
+    guildcount_id integer references guildcount(id) on delete set null,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+)
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS list_snapshots;
\ No newline at end of file
@@ -0,0 +1,18 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS list_snapshots(
+    id bigserial primary key,
+    service varchar(32) not null,
+    guild_count integer not null,
+    shard_count integer,
+    votes integer,
+    monthly_votes integer,
+    rank integer,
+    rating double precision,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+create index if not exists list_snapshots_service_created_at_idx
+    on list_snapshots (service, created_at desc);
+
+COMMIT;
\ No newline at end of file
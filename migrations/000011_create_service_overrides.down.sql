@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS service_override_audit;
+DROP TABLE IF EXISTS service_overrides;
+
+COMMIT;
\ No newline at end of file
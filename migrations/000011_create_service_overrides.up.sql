@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS service_overrides(
+    service varchar(32) primary key,
+    enabled boolean,
+    paused_until timestamp without time zone,
+    reason text,
+    updated_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+CREATE TABLE IF NOT EXISTS service_override_audit(
+    id bigserial primary key,
+    service varchar(32) not null,
+    action varchar(16) not null,
+    enabled boolean,
+    paused_until timestamp without time zone,
+    reason text,
+    actor text,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+create index if not exists service_override_audit_service_created_at_idx
+    on service_override_audit (service, created_at desc);
+
+COMMIT;
\ No newline at end of file
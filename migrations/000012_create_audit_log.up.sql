@@ -0,0 +1,22 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_log(
+    id bigserial primary key,
+    key_name varchar(64),
+    ip varchar(64),
+    method varchar(8) not null,
+    route text not null,
+    path text not null,
+    body text,
+    status_code integer not null,
+    error text,
+    created_at timestamp without time zone default (now() at time zone ('utc'))
+);
+
+create index if not exists audit_log_created_at_idx
+    on audit_log (created_at desc);
+
+create index if not exists audit_log_key_name_created_at_idx
+    on audit_log (key_name, created_at desc);
+
+COMMIT;
\ No newline at end of file
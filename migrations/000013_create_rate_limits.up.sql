@@ -0,0 +1,14 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS rate_limits(
+    key text not null,
+    window_start timestamp without time zone not null,
+    hits bigint not null default 0,
+    expires_at timestamp without time zone not null,
+    primary key (key, window_start)
+);
+
+create index if not exists rate_limits_expires_at_idx
+    on rate_limits (expires_at);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"sort"
+	"time"
+)
+
+// getMilestoneStep returns the configured step milestones are reached at, or 0 if only the listed thresholds count.
+func getMilestoneStep() int64 {
+	step := config.GetDefault("milestones.step", int64(0)).(int64)
+	if step < 0 {
+		return 0
+	}
+
+	return step
+}
+
+// crossedMilestones returns every milestone that lies above the previous guild count and at or below the current one.
+// Milestones are both the listed thresholds and every multiple of the step, if one is configured. Only the highest
+// multiple of the step crossed is returned, so that a jump in the guild count can't produce an unbounded amount.
+func crossedMilestones(previous int64, current int64) []int64 {
+	crossed := make(map[int64]bool)
+
+	for _, threshold := range getConfigIntArray("milestones.thresholds") {
+		if previous < threshold && current >= threshold {
+			crossed[threshold] = true
+		}
+	}
+
+	if step := getMilestoneStep(); step > 0 && current/step > previous/step {
+		crossed[current/step*step] = true
+	}
+
+	milestones := make([]int64, 0, len(crossed))
+	for milestone := range crossed {
+		milestones = append(milestones, milestone)
+	}
+
+	sort.Slice(milestones, func(i, j int) bool { return milestones[i] < milestones[j] })
+
+	return milestones
+}
+
+// getNextMilestone returns the lowest milestone above the guild count, or 0 if there are none left.
+func getNextMilestone(guildCount int64) int64 {
+	var next int64
+
+	for _, threshold := range getConfigIntArray("milestones.thresholds") {
+		if threshold > guildCount && (next == 0 || threshold < next) {
+			next = threshold
+		}
+	}
+
+	if step := getMilestoneStep(); step > 0 {
+		if milestone := (guildCount/step + 1) * step; next == 0 || milestone < next {
+			next = milestone
+		}
+	}
+
+	return next
+}
+
+// recordMilestones persists the milestones crossed by the new guild count. Milestones are only ever recorded once,
+// so only those reached for the first time are returned.
+func recordMilestones(previous int64, current int64, guildCountId int64) ([]Milestone, error) {
+	var milestones []Milestone
+
+	err := conn.BeginFunc(context.Background(), func(tx pgx.Tx) error {
+		for _, milestone := range crossedMilestones(previous, current) {
+			query := `insert into milestones(milestone, guild_count, previous_guild_count, guildcount_id)
+				values ($1, $2, $3, $4) on conflict (milestone) do nothing returning created_at`
+			rows, err := tx.Query(context.Background(), query, milestone, current, previous, guildCountId)
+			if err != nil {
+				return err
+			}
+
+			for rows.Next() {
+				var createdAt time.Time
+				if scanErr := rows.Scan(&createdAt); scanErr != nil {
+					rows.Close()
+					return scanErr
+				}
+
+				milestones = append(milestones, Milestone{
+					Milestone:          milestone,
+					GuildCount:         current,
+					PreviousGuildCount: previous,
+					Timestamp:          createdAt.UnixMilli(),
+				})
+			}
+
+			rows.Close()
+			if rowsErr := rows.Err(); rowsErr != nil {
+				return rowsErr
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return milestones, nil
+}
+
+// getMilestones returns every milestone reached, highest first.
+func getMilestones() ([]Milestone, error) {
+	query := "select milestone, guild_count, previous_guild_count, created_at from milestones order by milestone desc"
+	rows, err := queryRows(query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	milestones := make([]Milestone, 0)
+	for rows.Next() {
+		var milestone Milestone
+		var createdAt time.Time
+		scanErr := rows.Scan(&milestone.Milestone, &milestone.GuildCount, &milestone.PreviousGuildCount, &createdAt)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		milestone.Timestamp = createdAt.UnixMilli()
+		milestones = append(milestones, milestone)
+	}
+
+	return milestones, rows.Err()
+}
+
+func announceMilestones(milestones []Milestone) {
+	for _, milestone := range milestones {
+		emitEventAsync("milestone.reached", milestone)
+		notifyDiscord(DiscordEmbed{
+			Title:       "Milestone reached",
+			Description: fmt.Sprintf("Suggestions is now in **%d** servers!", milestone.Milestone),
+			Color:       discordColorGreen,
+		})
+	}
+}
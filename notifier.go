@@ -0,0 +1,205 @@
+package main
+
+import (
+	bytes2 "bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jackc/pgx/v4"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	discordColorRed    = 0xed4245
+	discordColorYellow = 0xfee75c
+	discordColorGreen  = 0x57f287
+)
+
+// serviceHealth tracks consecutive post failures, rejected tokens and detected drift per service, so that notifications
+// are only sent when something changes rather than on every post.
+type serviceHealth struct {
+	mutex     sync.Mutex
+	failures  map[string]int
+	badTokens map[string]bool
+	drifting  map[string]bool
+}
+
+var health = &serviceHealth{
+	failures:  make(map[string]int),
+	badTokens: make(map[string]bool),
+	drifting:  make(map[string]bool),
+}
+
+func isDiscordNotifierEnabled() bool {
+	return config.GetDefault("notifications.discord.enabled", false).(bool) && getSecret("DISCORD_WEBHOOK_URL") != ""
+}
+
+// notifyDiscord posts the embed to the configured Discord webhook without holding up the caller.
+func notifyDiscord(embed DiscordEmbed) {
+	if !isDiscordNotifierEnabled() {
+		return
+	}
+
+	embed.Timestamp = time.Now().UTC().Format(time.RFC3339)
+
+	go func() {
+		if err := sendDiscordWebhook(embed); err != nil {
+			log.Printf("Failed to send the Discord notification '%s': %s", embed.Title, err)
+		}
+	}()
+}
+
+func sendDiscordWebhook(embed DiscordEmbed) error {
+	jsonData, jsonErr := json.Marshal(DiscordWebhookBody{
+		Username: "Lists",
+		Embeds:   []DiscordEmbed{embed},
+	})
+	if jsonErr != nil {
+		return jsonErr
+	}
+
+	req, err := http.NewRequest("POST", getSecret("DISCORD_WEBHOOK_URL"), bytes2.NewBuffer(jsonData))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: time.Second * 30}
+	resp, respErr := client.Do(req)
+	if respErr != nil {
+		return respErr
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Discord responded with status %d.", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// getLatestGuildCount returns the most recently posted guild count, and whether one has been posted at all.
+func getLatestGuildCount() (int64, bool, error) {
+	var guildCount int64
+	query := "select guild_count from guildcount where shard_count is not null order by created_at desc"
+	err := queryRow(query, &guildCount)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, false, nil
+	}
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	return guildCount, true, nil
+}
+
+// recordPostResult tracks the outcome of posting to a service. A notification is sent once the service reaches the
+// configured amount of consecutive failures, and the first time the service rejects the token until a post succeeds.
+func recordPostResult(service string, err error) {
+	health.mutex.Lock()
+	defer health.mutex.Unlock()
+
+	if err == nil {
+		health.failures[service] = 0
+		health.badTokens[service] = false
+		return
+	}
+
+	health.failures[service]++
+	failures := health.failures[service]
+
+	var botListErr *BotListError
+	if errors.As(err, &botListErr) && !health.badTokens[service] {
+		if botListErr.StatusCode == http.StatusUnauthorized || botListErr.StatusCode == http.StatusForbidden {
+			health.badTokens[service] = true
+			notifyDiscord(DiscordEmbed{
+				Title:       "Bad token",
+				Description: fmt.Sprintf("**%s** rejected the stats with status %d, its token may have expired.", service, botListErr.StatusCode),
+				Color:       discordColorRed,
+			})
+		}
+	}
+
+	threshold := int(config.GetDefault("notifications.discord.failure_threshold", int64(3)).(int64))
+	if failures == threshold {
+		notifyDiscord(DiscordEmbed{
+			Title:       "Posting stats is failing",
+			Description: fmt.Sprintf("Posting stats to **%s** has failed %d times in a row.", service, failures),
+			Color:       discordColorRed,
+			Fields: []DiscordEmbedField{
+				{Name: "Last error", Value: err.Error()},
+			},
+		})
+	}
+}
+
+// checkDrift compares the guild count displayed on each list against the most recently posted guild count. Drift is
+// reported when a list starts to differ by more than the configured threshold, and again once it stops drifting.
+func checkDrift(responses []BotListServiceResponse) {
+	guildCount, posted, err := getLatestGuildCount()
+	if err != nil {
+		log.Printf("Failed to check the bot lists for drift: %s", err)
+		return
+	}
+
+	if !posted {
+		return
+	}
+
+	threshold := config.GetDefault("notifications.drift_threshold", int64(500)).(int64)
+
+	health.mutex.Lock()
+	defer health.mutex.Unlock()
+
+	for _, response := range responses {
+		if response.Error {
+			continue
+		}
+
+		difference := response.GuildCount - guildCount
+		if difference < 0 {
+			difference = -difference
+		}
+
+		drifting := difference > threshold
+		if drifting == health.drifting[response.ShortName] {
+			continue
+		}
+
+		health.drifting[response.ShortName] = drifting
+
+		if !drifting {
+			notifyDiscord(DiscordEmbed{
+				Title:       "Drift resolved",
+				Description: fmt.Sprintf("**%s** is displaying the correct guild count again.", response.ShortName),
+				Color:       discordColorGreen,
+			})
+			continue
+		}
+
+		drift := ServiceDrift{
+			Service:           response.ShortName,
+			GuildCount:        guildCount,
+			DisplayedGuilds:   response.GuildCount,
+			Difference:        response.GuildCount - guildCount,
+			DifferenceAllowed: threshold,
+		}
+
+		emitEventAsync("service.drift", drift)
+		notifyDiscord(DiscordEmbed{
+			Title:       "Drift detected",
+			Description: fmt.Sprintf("**%s** is displaying a guild count that differs from the posted one.", response.ShortName),
+			Color:       discordColorYellow,
+			Fields: []DiscordEmbedField{
+				{Name: "Posted", Value: fmt.Sprint(drift.GuildCount), Inline: true},
+				{Name: "Displayed", Value: fmt.Sprint(drift.DisplayedGuilds), Inline: true},
+			},
+		})
+	}
+}
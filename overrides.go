@@ -0,0 +1,222 @@
+package main
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4"
+	"log"
+	"sync"
+	"time"
+)
+
+// serviceOverrideCache holds the overrides set through the admin routes. They are consulted every time the active
+// services are resolved, so they are only reloaded from the database once the refresh interval has passed, or right
+// away after this instance changed one. Commands run without a database connection, so only the config applies to them.
+type serviceOverrideCache struct {
+	mutex     sync.Mutex
+	overrides map[string]ServiceOverride
+	fetchedAt time.Time
+}
+
+var serviceOverrides = &serviceOverrideCache{overrides: make(map[string]ServiceOverride)}
+
+func (c *serviceOverrideCache) get() map[string]ServiceOverride {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if conn == nil {
+		return c.overrides
+	}
+
+	if time.Since(c.fetchedAt) < getConfigDuration("overrides.refresh_interval", time.Second*30) {
+		return c.overrides
+	}
+
+	overrides, err := getServiceOverrides()
+	if err != nil {
+		log.Printf("Failed to load the service overrides, using the previously loaded ones: %s", err)
+		return c.overrides
+	}
+
+	c.overrides = overrides
+	c.fetchedAt = time.Now()
+
+	return c.overrides
+}
+
+func (c *serviceOverrideCache) invalidate() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.fetchedAt = time.Time{}
+}
+
+// isServiceActive decides whether a service is active, with its override taking precedence over the config. A paused
+// service is inactive until the pause has passed.
+func isServiceActive(service string, configEnabled bool, overrides map[string]ServiceOverride, now time.Time) bool {
+	override, ok := overrides[service]
+	if !ok {
+		return configEnabled
+	}
+
+	if override.PausedUntil > now.UnixMilli() {
+		return false
+	}
+
+	if override.Enabled != nil {
+		return *override.Enabled
+	}
+
+	return configEnabled
+}
+
+func getServiceOverrides() (map[string]ServiceOverride, error) {
+	rows, err := queryRows("select service, enabled, paused_until, reason, updated_at from service_overrides")
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	overrides := make(map[string]ServiceOverride)
+	for rows.Next() {
+		override, scanErr := scanServiceOverride(rows)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		overrides[override.Service] = *override
+	}
+
+	return overrides, rows.Err()
+}
+
+func scanServiceOverride(row pgx.Row) (*ServiceOverride, error) {
+	var override ServiceOverride
+	var pausedUntil *time.Time
+	var reason *string
+	var updatedAt time.Time
+	if err := row.Scan(&override.Service, &override.Enabled, &pausedUntil, &reason, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	if pausedUntil != nil {
+		override.PausedUntil = pausedUntil.UnixMilli()
+	}
+
+	if reason != nil {
+		override.Reason = *reason
+	}
+
+	override.UpdatedAt = updatedAt.UnixMilli()
+
+	return &override, nil
+}
+
+// setServiceOverride stores the override and records the change in the audit log. Enabling or disabling a service
+// lifts any pause, while pausing it keeps whether it was enabled or disabled.
+func setServiceOverride(service string, action string, enabled *bool, pausedUntil *time.Time, reason string, actor string) (*ServiceOverride, error) {
+	var override *ServiceOverride
+	err := conn.BeginFunc(context.Background(), func(tx pgx.Tx) error {
+		query := `insert into service_overrides(service, enabled, paused_until, reason, updated_at)
+			values ($1, $2, $3, $4, now() at time zone ('utc'))
+			on conflict (service) do update set
+				enabled = coalesce(excluded.enabled, service_overrides.enabled),
+				paused_until = excluded.paused_until,
+				reason = excluded.reason,
+				updated_at = excluded.updated_at
+			returning service, enabled, paused_until, reason, updated_at`
+		scanned, scanErr := scanServiceOverride(tx.QueryRow(context.Background(), query, service, enabled, pausedUntil, reason))
+		if scanErr != nil {
+			return scanErr
+		}
+
+		override = scanned
+
+		return insertServiceOverrideAudit(tx, service, action, override.Enabled, pausedUntil, reason, actor)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	serviceOverrides.invalidate()
+	log.Printf("The service %s was %s by %s.", service, action, actor)
+
+	return override, nil
+}
+
+// deleteServiceOverride removes the override so that the service follows the config again, returning whether there
+// was one to remove.
+func deleteServiceOverride(service string, reason string, actor string) (bool, error) {
+	deleted := false
+	err := conn.BeginFunc(context.Background(), func(tx pgx.Tx) error {
+		tag, execErr := tx.Exec(context.Background(), "delete from service_overrides where service = $1", service)
+		if execErr != nil {
+			return execErr
+		}
+
+		deleted = tag.RowsAffected() > 0
+		if !deleted {
+			return nil
+		}
+
+		return insertServiceOverrideAudit(tx, service, "reset", nil, nil, reason, actor)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	serviceOverrides.invalidate()
+	if deleted {
+		log.Printf("The override of the service %s was reset by %s.", service, actor)
+	}
+
+	return deleted, nil
+}
+
+func insertServiceOverrideAudit(tx pgx.Tx, service string, action string, enabled *bool, pausedUntil *time.Time, reason string, actor string) error {
+	query := `insert into service_override_audit(service, action, enabled, paused_until, reason, actor)
+		values ($1, $2, $3, $4, $5, $6)`
+	_, err := tx.Exec(context.Background(), query, service, action, enabled, pausedUntil, reason, actor)
+
+	return err
+}
+
+// getServiceOverrideAudit returns the most recent changes made to the service's override.
+func getServiceOverrideAudit(service string, limit int64) ([]ServiceOverrideAuditEntry, error) {
+	query := `select id, action, enabled, paused_until, reason, actor, created_at from service_override_audit
+		where service = $1 order by created_at desc, id desc limit $2`
+	rows, err := queryRows(query, service, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := make([]ServiceOverrideAuditEntry, 0)
+	for rows.Next() {
+		entry := ServiceOverrideAuditEntry{Service: service}
+		var pausedUntil *time.Time
+		var reason, actor *string
+		var createdAt time.Time
+		if scanErr := rows.Scan(&entry.Id, &entry.Action, &entry.Enabled, &pausedUntil, &reason, &actor, &createdAt); scanErr != nil {
+			return nil, scanErr
+		}
+
+		if pausedUntil != nil {
+			entry.PausedUntil = pausedUntil.UnixMilli()
+		}
+
+		if reason != nil {
+			entry.Reason = *reason
+		}
+
+		if actor != nil {
+			entry.Actor = *actor
+		}
+
+		entry.Timestamp = createdAt.UnixMilli()
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
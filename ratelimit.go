@@ -0,0 +1,211 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimitStore counts the requests made by each client within fixed windows.
+type rateLimitStore interface {
+	// increment counts a request against the key within the window starting at the given time, returning the amount
+	// of requests counted so far.
+	increment(key string, window time.Time, length time.Duration) (int64, error)
+}
+
+type rateLimitCounter struct {
+	window    time.Time
+	expiresAt time.Time
+	hits      int64
+}
+
+// memoryRateLimitStore keeps the counters in memory, so each instance enforces the limits on its own.
+type memoryRateLimitStore struct {
+	mutex    sync.Mutex
+	counters map[string]*rateLimitCounter
+	sweptAt  time.Time
+}
+
+func newMemoryRateLimitStore() *memoryRateLimitStore {
+	return &memoryRateLimitStore{counters: make(map[string]*rateLimitCounter), sweptAt: time.Now()}
+}
+
+func (s *memoryRateLimitStore) increment(key string, window time.Time, length time.Duration) (int64, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.sweptAt) > time.Minute {
+		for counterKey, counter := range s.counters {
+			if counter.expiresAt.Before(now) {
+				delete(s.counters, counterKey)
+			}
+		}
+
+		s.sweptAt = now
+	}
+
+	counter, ok := s.counters[key]
+	if !ok || !counter.window.Equal(window) {
+		counter = &rateLimitCounter{window: window, expiresAt: window.Add(length)}
+		s.counters[key] = counter
+	}
+
+	counter.hits++
+
+	return counter.hits, nil
+}
+
+// postgresRateLimitStore keeps the counters in the database, so the limits are shared by every instance.
+type postgresRateLimitStore struct {
+	mutex   sync.Mutex
+	sweptAt time.Time
+}
+
+func (s *postgresRateLimitStore) increment(key string, window time.Time, length time.Duration) (int64, error) {
+	s.mutex.Lock()
+	if time.Since(s.sweptAt) > time.Minute {
+		s.sweptAt = time.Now()
+		go func() {
+			if _, err := execQuery("delete from rate_limits where expires_at < (now() at time zone 'utc')"); err != nil {
+				log.Printf("Failed to delete the expired rate limits: %s", err)
+			}
+		}()
+	}
+	s.mutex.Unlock()
+
+	var hits int64
+	query := "insert into rate_limits (key, window_start, hits, expires_at) values ($1, $2, 1, $3) " +
+		"on conflict (key, window_start) do update set hits = rate_limits.hits + 1 returning hits"
+	err := conn.QueryRow(context.Background(), query, key, window.UTC(), window.Add(length).UTC()).Scan(&hits)
+
+	return hits, err
+}
+
+var rateLimits rateLimitStore
+
+// newRateLimitStore creates the store set in the config, falling back to the memory store.
+func newRateLimitStore() rateLimitStore {
+	switch store := config.GetDefault("ratelimit.store", "memory").(string); store {
+	case "postgres":
+		return &postgresRateLimitStore{}
+	case "memory":
+		return newMemoryRateLimitStore()
+	default:
+		log.Printf("Unknown rate limit store '%s', using the memory store instead.", store)
+		return newMemoryRateLimitStore()
+	}
+}
+
+// rateLimitCheck is a limit a request is counted against, identified by the group and the client it limits.
+type rateLimitCheck struct {
+	key string
+	max int64
+}
+
+// rateLimitResult is the state of a single limit after counting a request against it.
+type rateLimitResult struct {
+	limit     int64
+	remaining int64
+	reset     time.Duration
+}
+
+// countRateLimit counts the request against the limit of the key, returning whether it's within the limit.
+func countRateLimit(key string, max int64, length time.Duration, now time.Time) (rateLimitResult, bool, error) {
+	window := now.Truncate(length)
+
+	hits, err := rateLimits.increment(key, window, length)
+	if err != nil {
+		return rateLimitResult{}, true, err
+	}
+
+	result := rateLimitResult{
+		limit:     max,
+		remaining: max - hits,
+		reset:     window.Add(length).Sub(now),
+	}
+	if result.remaining < 0 {
+		result.remaining = 0
+	}
+
+	return result, hits <= max, nil
+}
+
+// setRateLimitHeaders sets the RateLimit headers to the limit closest to being reached, as a request can be counted
+// against the limits of more than one group.
+func setRateLimitHeaders(ctx *fiber.Ctx, result rateLimitResult) {
+	if current, ok := ctx.Locals("ratelimit").(rateLimitResult); ok && current.remaining < result.remaining {
+		return
+	}
+
+	ctx.Locals("ratelimit", result)
+	ctx.Set("RateLimit-Limit", strconv.FormatInt(result.limit, 10))
+	ctx.Set("RateLimit-Remaining", strconv.FormatInt(result.remaining, 10))
+	ctx.Set("RateLimit-Reset", strconv.FormatInt(int64(math.Ceil(result.reset.Seconds())), 10))
+}
+
+const (
+	rateLimitByKey = "key"
+	rateLimitByIp  = "ip"
+)
+
+// rateLimit limits the requests to the route group per API key, then per IP, using the limits set in the group's
+// config. Passing scopes only enforces those limits, so that the IP limit can run before the API key is checked and
+// also count the requests that fail to authenticate. A limit that isn't set isn't enforced, and requests are let
+// through if they can't be counted.
+func rateLimit(group string, scopes ...string) fiber.Handler {
+	if len(scopes) == 0 {
+		scopes = []string{rateLimitByKey, rateLimitByIp}
+	}
+
+	prefix := fmt.Sprintf("ratelimit.groups.%s", group)
+	length := getConfigDuration(prefix+".window", time.Minute)
+
+	var keyMax, ipMax int64
+	for _, scope := range scopes {
+		switch scope {
+		case rateLimitByKey:
+			keyMax = config.GetDefault(prefix+".key_max", int64(0)).(int64)
+		case rateLimitByIp:
+			ipMax = config.GetDefault(prefix+".ip_max", int64(0)).(int64)
+		}
+	}
+
+	return func(ctx *fiber.Ctx) error {
+		if !config.GetDefault("ratelimit.enabled", false).(bool) {
+			return ctx.Next()
+		}
+
+		// The limits are checked in a fixed order, so a request over both is always reported against its key.
+		limits := make([]rateLimitCheck, 0, 2)
+		if name := getApiKeyName(ctx); name != "" && keyMax > 0 {
+			limits = append(limits, rateLimitCheck{key: fmt.Sprintf("%s:key:%s", group, name), max: keyMax})
+		}
+		if ipMax > 0 {
+			limits = append(limits, rateLimitCheck{key: fmt.Sprintf("%s:ip:%s", group, ctx.IP()), max: ipMax})
+		}
+
+		now := time.Now()
+		for _, limit := range limits {
+			result, allowed, err := countRateLimit(limit.key, limit.max, length, now)
+			if err != nil {
+				log.Printf("Failed to count the request against the rate limit of %s: %s", limit.key, err)
+				continue
+			}
+
+			setRateLimitHeaders(ctx, result)
+
+			if !allowed {
+				ctx.Set(fiber.HeaderRetryAfter, strconv.FormatInt(int64(math.Ceil(result.reset.Seconds())), 10))
+				return fiber.NewError(fiber.StatusTooManyRequests, "You are being rate limited.")
+			}
+		}
+
+		return ctx.Next()
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"github.com/jackc/pgx/v4"
+	"log"
+	"time"
+)
+
+func upsertReminderSubscription(body ReminderSubscriptionRequestBody) (*ReminderSubscription, error) {
+	var services interface{}
+	if len(body.Services) > 0 {
+		services = body.Services
+	}
+
+	query := `insert into reminder_subscriptions(user_id, services) values ($1, $2)
+		on conflict (user_id) do update set services = excluded.services`
+	_, err := execQuery(query, body.UserId, services)
+	if err != nil {
+		return nil, err
+	}
+
+	return getReminderSubscription(body.UserId)
+}
+
+// getReminderSubscription returns the user's subscription along with their pending reminders, or nil if the user
+// hasn't opted in to reminders.
+func getReminderSubscription(userId string) (*ReminderSubscription, error) {
+	subscription := &ReminderSubscription{UserId: userId, Pending: make([]VoteReminder, 0)}
+
+	var createdAt time.Time
+	query := "select services, created_at from reminder_subscriptions where user_id = $1"
+	err := conn.QueryRow(context.Background(), query, userId).Scan(&subscription.Services, &createdAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Timestamp = createdAt.UnixMilli()
+
+	reminderQuery := `select id, user_id, service, remind_at from vote_reminders
+		where user_id = $1 and delivered_at is null order by remind_at`
+	rows, rowsErr := queryRows(reminderQuery, userId)
+	if rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var reminder VoteReminder
+		var remindAt time.Time
+		if scanErr := rows.Scan(&reminder.Id, &reminder.UserId, &reminder.Service, &remindAt); scanErr != nil {
+			return nil, scanErr
+		}
+
+		reminder.RemindAt = remindAt.UnixMilli()
+		subscription.Pending = append(subscription.Pending, reminder)
+	}
+
+	return subscription, rows.Err()
+}
+
+// deleteReminderSubscription opts the user out of reminders and drops any reminders still pending for them, returning
+// whether the user had a subscription.
+func deleteReminderSubscription(userId string) (bool, error) {
+	_, err := execQuery("delete from vote_reminders where user_id = $1 and delivered_at is null", userId)
+	if err != nil {
+		return false, err
+	}
+
+	tag, deleteErr := execQuery("delete from reminder_subscriptions where user_id = $1", userId)
+	if deleteErr != nil {
+		return false, deleteErr
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// scheduleVoteReminder schedules a reminder for when the list's cooldown expires if the voting user opted in to
+// reminders for the list. Any reminder still pending for the same user and list is replaced.
+func scheduleVoteReminder(vote *Vote) error {
+	if !config.GetDefault("reminders.enabled", false).(bool) {
+		return nil
+	}
+
+	var services []string
+	query := "select services from reminder_subscriptions where user_id = $1"
+	err := conn.QueryRow(context.Background(), query, vote.UserId).Scan(&services)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if len(services) > 0 {
+		subscribed := false
+		for _, service := range services {
+			if service == vote.Service {
+				subscribed = true
+				break
+			}
+		}
+
+		if !subscribed {
+			return nil
+		}
+	}
+
+	remindAt := time.UnixMilli(vote.Timestamp).UTC().Add(getServiceVoteConfig(vote.Service).Cooldown)
+
+	return conn.BeginFunc(context.Background(), func(tx pgx.Tx) error {
+		deleteQuery := "delete from vote_reminders where user_id = $1 and service = $2 and delivered_at is null"
+		_, deleteErr := tx.Exec(context.Background(), deleteQuery, vote.UserId, vote.Service)
+		if deleteErr != nil {
+			return deleteErr
+		}
+
+		insertQuery := "insert into vote_reminders(user_id, service, vote_id, remind_at) values ($1, $2, $3, $4)"
+		_, insertErr := tx.Exec(context.Background(), insertQuery, vote.UserId, vote.Service, vote.Id, remindAt)
+
+		return insertErr
+	})
+}
+
+// startReminderScheduler periodically delivers reminders that are due. Reminders are persisted, so any that came due
+// while the service was down are delivered on the first run.
+func startReminderScheduler() {
+	if !config.GetDefault("reminders.enabled", false).(bool) {
+		return
+	}
+
+	interval := getConfigDuration("reminders.interval", time.Second*30)
+
+	if err := syncReminderSubscription(); err != nil {
+		log.Printf("Failed to subscribe the reminders URL to vote reminders: %s", err)
+	}
+
+	go func() {
+		for {
+			if err := deliverDueReminders(); err != nil {
+				log.Printf("Failed to deliver vote reminders: %s", err)
+			}
+
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// syncReminderSubscription subscribes the configured reminders URL to vote.reminder events, so that reminders are
+// delivered to it with the same signing, retries and delivery log as every other subscriber.
+func syncReminderSubscription() error {
+	reminderUrl := config.GetDefault("reminders.url", "").(string)
+	if reminderUrl == "" {
+		return nil
+	}
+
+	secret := getSecret("REMINDERS_WEBHOOK_TOKEN")
+	if secret == "" {
+		return errors.New("REMINDERS_WEBHOOK_TOKEN must be set to sign the reminders delivered to the reminders URL.")
+	}
+
+	events := []string{"vote.reminder"}
+	tag, err := execQuery("update subscriptions set secret = $3 where url = $1 and events = $2", reminderUrl, events, secret)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() > 0 {
+		return nil
+	}
+
+	_, insertErr := insertSubscription(SubscriptionRequestBody{Url: reminderUrl, Events: events, Secret: secret})
+
+	return insertErr
+}
+
+// deliverDueReminders emits a vote.reminder event for every reminder that is due, which is delivered to the reminders
+// URL and the subscriptions listening for it like any other event. Reminders are marked as delivered in the same
+// transaction the event is emitted in, so that each reminder is only ever emitted once.
+func deliverDueReminders() error {
+	var events []Event
+
+	err := conn.BeginFunc(context.Background(), func(tx pgx.Tx) error {
+		query := `select id, user_id, service, remind_at from vote_reminders
+			where delivered_at is null and remind_at <= (now() at time zone ('utc'))
+			order by remind_at
+			limit 50
+			for update skip locked`
+		rows, err := tx.Query(context.Background(), query)
+		if err != nil {
+			return err
+		}
+
+		var reminders []VoteReminder
+		for rows.Next() {
+			var reminder VoteReminder
+			var remindAt time.Time
+			if scanErr := rows.Scan(&reminder.Id, &reminder.UserId, &reminder.Service, &remindAt); scanErr != nil {
+				rows.Close()
+				return scanErr
+			}
+
+			reminder.RemindAt = remindAt.UnixMilli()
+			reminders = append(reminders, reminder)
+		}
+
+		rows.Close()
+		if rowsErr := rows.Err(); rowsErr != nil {
+			return rowsErr
+		}
+
+		for _, reminder := range reminders {
+			event, eventErr := insertEvent(tx, "vote.reminder", VoteReminderEvent{
+				UserId:   reminder.UserId,
+				Service:  reminder.Service,
+				VoteUrl:  getServiceVoteConfig(reminder.Service).Url,
+				RemindAt: reminder.RemindAt,
+			})
+			if eventErr != nil {
+				return eventErr
+			}
+
+			updateQuery := "update vote_reminders set delivered_at = (now() at time zone ('utc')) where id = $1"
+			if _, execErr := tx.Exec(context.Background(), updateQuery, reminder.Id); execErr != nil {
+				return execErr
+			}
+
+			events = append(events, *event)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		broker.publish(event)
+	}
+
+	return nil
+}
@@ -1,20 +1,27 @@
 package main
 
 import (
+	"bufio"
+	"crypto/subtle"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
-	"net/http"
+	"github.com/gofiber/fiber/v2/utils"
+	"github.com/pelletier/go-toml"
+	"log"
+	"sort"
+	"strconv"
 	"time"
 )
 
 // postGuildCountRoute is a function that used to post guild count information to all bot lists and be persisted in the database.
 //
 //	@Summary		Post guild stats to bot lists and persist them in the database.
-//	@Description	The guild count and shard count are persisted to the database then posted to all active bot lists set in the config.
+//	@Description	The guild count and shard count (optionally broken down per shard) along with any additional stats are persisted to the database then posted to all active bot lists set in the config. Each list receives the stats mapped in its fields config. The targeted lists can be narrowed down with an include and exclude list of service names or config-defined service groups. When dry_run is set nothing is persisted or posted, instead a preview of the exact request that would be sent to each targeted list is returned with the token redacted.
 //	@tags			General
 //	@Accept			json
 //	@Produce		json
 //	@Success		200				{object}	ResponseHTTP{data=GuildCountResponse}
+//	@Failure		400				{object}	ResponseHTTPError{}
 //	@Failure		503				{object}	ResponseHTTPError{}
 //
 //	@Param			Authorization	header		string					true	"The required API key"
@@ -34,31 +41,72 @@ func postGuildCountRoute(ctx *fiber.Ctx) error {
 		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
 	}
 
+	services := selectServices(guild.Services)
+	if len(services) < 1 {
+		return fiber.NewError(fiber.StatusBadRequest, "No active services match the service selection.")
+	}
+
 	if !guild.DryRun {
-		query := "insert into guildcount(guild_count, shard_count) values ($1, $2) returning *"
-		_, execErr := execQuery(query, guild.Guilds, guild.Shards)
-		if execErr != nil {
-			return fiber.NewError(fiber.StatusInternalServerError, execErr.Error())
+		// Milestones are detected on a best effort basis, so that failing to do so never stops the stats being posted.
+		previous, posted, latestErr := getLatestGuildCount()
+		if latestErr != nil {
+			log.Printf("Failed to get the previous guild count to detect milestones: %s", latestErr)
+		}
+
+		guildCountId, insertErr := insertGuildCount(*guild)
+		if insertErr != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, insertErr.Error())
+		}
+
+		if posted && latestErr == nil {
+			milestones, milestoneErr := recordMilestones(previous, guild.Guilds, guildCountId)
+			if milestoneErr != nil {
+				log.Printf("Failed to record the milestones reached by the guild count: %s", milestoneErr)
+			}
+
+			announceMilestones(milestones)
+		}
+
+		emitEventAsync("guilds.posted", GuildCountResponse{
+			Guilds:     guild.Guilds,
+			Shards:     guild.Shards,
+			ShardStats: guild.ShardStats,
+			Stats:      guild.Stats,
+			Services:   services,
+			Timestamp:  time.Now().UnixMilli(),
+		})
+
+		postErrors := postStatsToBotLists(*guild, services)
+		for _, postErr := range postErrors {
+			emitEventAsync("service.post_failed", postErr)
 		}
 
-		postErrors := postStatsToBotLists(guild.Guilds, guild.Shards)
 		if len(postErrors) > 0 {
 			return handleBotListErrors(ctx, postErrors)
 		}
 	}
 
+	var previews []ServiceRequestPreview
+	if guild.DryRun {
+		previews = previewStatsRequests(*guild, services)
+	}
+
 	return ctx.JSON(formJsonBody(GuildCountResponse{
-		Guilds:    guild.Guilds,
-		Shards:    guild.Shards,
-		DryRun:    guild.DryRun,
-		Timestamp: time.Now().UnixMilli(),
+		Guilds:     guild.Guilds,
+		Shards:     guild.Shards,
+		ShardStats: guild.ShardStats,
+		Stats:      guild.Stats,
+		Services:   services,
+		Previews:   previews,
+		DryRun:     guild.DryRun,
+		Timestamp:  time.Now().UnixMilli(),
 	}, true))
 }
 
 // getGuildCountRoute is a function that returns the most recently committed guild count in the database.
 //
 //	@Summary		Get the recent guild count from the database.
-//	@Description	The most recently posted guild and shard count in the database is returned as well as the timestamp of when this data was committed. This data reflects the guild count on the active bot lists.
+//	@Description	The most recently posted guild and shard count in the database is returned as well as the timestamp of when this data was committed. This data reflects the guild count on the active bot lists. If a per-shard breakdown was posted, it is included as well.
 //	@tags			General
 //	@Accept			json
 //	@Produce		json
@@ -69,29 +117,113 @@ func postGuildCountRoute(ctx *fiber.Ctx) error {
 //
 //	@Router			/api/v1/guilds [get]
 func getGuildCountRoute(ctx *fiber.Ctx) error {
+	var id int64
 	var guildCount int64
 	var shardCount int64
+	var stats map[string]int64
 	var createdAt time.Time
 
-	query := "select guild_count, shard_count, created_at from guildcount where shard_count is not null order by created_at desc"
-	err := queryRow(query, &guildCount, &shardCount, &createdAt)
+	query := "select id, guild_count, shard_count, stats, created_at from guildcount where shard_count is not null order by created_at desc"
+	err := queryRow(query, &id, &guildCount, &shardCount, &stats, &createdAt)
 	if err != nil {
 		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 	}
 
+	shardStats, shardErr := getShardStats(id)
+	if shardErr != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, shardErr.Error())
+	}
+
 	return ctx.JSON(formJsonBody(
 		GuildCountResponse{
-			Guilds:    guildCount,
-			Shards:    shardCount,
-			Timestamp: createdAt.UnixMilli(),
+			Guilds:     guildCount,
+			Shards:     shardCount,
+			ShardStats: shardStats,
+			Stats:      stats,
+			Timestamp:  createdAt.UnixMilli(),
 		}, true,
 	))
 }
 
+// getGuildStatsRoute is a function that returns growth analytics based on the guild count history.
+//
+//	@Summary		Get growth analytics for the guild count.
+//	@Description	Returns the net change and growth rate over the last 24 hours, 7 days and 30 days, estimated daily joins and leaves for the last 30 days with each day's closing count and its 7 day moving average, and a projection of when the next milestone will be reached based on a linear regression over the last 30 days. The projection is omitted when the milestone is more than 10 years away at the current rate.
+//	@tags			General
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=GuildStatsResponse}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Router			/api/v1/guilds/stats [get]
+func getGuildStatsRoute(ctx *fiber.Ctx) error {
+	now := time.Now()
+
+	points, err := getGuildCountHistory(now.AddDate(0, 0, -30))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	var guildCount int64
+	if len(points) > 0 {
+		guildCount = points[len(points)-1].Guilds
+	}
+
+	return ctx.JSON(formJsonBody(
+		GuildStatsResponse{
+			GuildCount: guildCount,
+			Windows: []GrowthWindow{
+				calculateGrowthWindow(points, "24h", time.Hour*24, now),
+				calculateGrowthWindow(points, "7d", time.Hour*24*7, now),
+				calculateGrowthWindow(points, "30d", time.Hour*24*30, now),
+			},
+			Daily:      calculateDailyGrowth(points, 30, 7, now),
+			Projection: calculateProjection(points, now),
+			Timestamp:  now.UnixMilli(),
+		},
+		true,
+	))
+}
+
+// getMilestonesRoute is a function that returns every guild count milestone reached.
+//
+//	@Summary		Get all guild count milestones reached.
+//	@Description	Milestones are the thresholds listed in the config as well as every multiple of the configured step. They are detected when a posted guild count crosses them, and are only ever recorded once. The next milestone to be reached is returned as well.
+//	@tags			General
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=MilestonesResponse}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Router			/api/v1/milestones [get]
+func getMilestonesRoute(ctx *fiber.Ctx) error {
+	milestones, err := getMilestones()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	guildCount, _, latestErr := getLatestGuildCount()
+	if latestErr != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, latestErr.Error())
+	}
+
+	return ctx.JSON(formJsonBody(
+		MilestonesResponse{
+			Milestones: milestones,
+			Next:       getNextMilestone(guildCount),
+		},
+		true,
+	))
+}
+
 // getBotListServicesRoute is a function to get an overview of all active lists the bot is on.
 //
 //	@Summary		Get all active lists the bot is on.
-//	@Description	This function returns the timestamp of when guild stats were lasted committed to the database as well as an overview of all information from bot lists that are marked active via the config.
+//	@Description	This function returns the timestamp of when guild stats were lasted committed to the database as well as an overview of all information from bot lists that are marked active via the config. The bot list data is cached, cache_age is how old the returned data is in milliseconds. Stale data is served while it is refreshed in the background. Each service includes the state of its circuit breaker, which skips requests to a list after it failed too many times in a row.
 //	@tags			General
 //	@Accept			json
 //	@Produce		json
@@ -100,9 +232,11 @@ func getGuildCountRoute(ctx *fiber.Ctx) error {
 //
 //	@Param			Authorization	header		string	true	"The required API key"
 //
+//	@Param			fresh			query		bool	false	"Bypass the cache and fetch live data from every bot list."
+//
 //	@Router			/api/v1/services [get]
 func getBotListServicesRoute(ctx *fiber.Ctx) error {
-	responses, errors := fetchBotListServiceData()
+	responses, age, errors := servicesCache.get(ctx.Query("fresh") == "true")
 	if len(errors) > 0 {
 		return handleBotListErrors(ctx, errors)
 	}
@@ -116,8 +250,9 @@ func getBotListServicesRoute(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(formJsonBody(
 		BotListServicesResponse{
-			Services:    responses,
+			Services:    withBreakerStatus(responses),
 			LastUpdated: timestamp.UnixMilli(),
+			CacheAge:    age.Milliseconds(),
 		},
 		true,
 	))
@@ -149,8 +284,7 @@ func getSingleBotListServiceRoute(ctx *fiber.Ctx) error {
 	for _, s := range activeServices {
 		if s == service {
 			config := getServiceConfig(service)
-			client := &http.Client{Timeout: time.Second * 30}
-			data, err := fetchStats(client, config)
+			data, err := fetchStats(config)
 			if err != nil {
 				return fiber.NewError(fiber.StatusInternalServerError, err.Error())
 			}
@@ -174,9 +308,1251 @@ func getSingleBotListServiceRoute(ctx *fiber.Ctx) error {
 
 	return ctx.JSON(formJsonBody(
 		BotListServicesResponse{
-			Services:    services,
+			Services:    withBreakerStatus(services),
 			LastUpdated: timestamp.UnixMilli(),
 		},
 		true,
 	))
 }
+
+// getServiceHistoryRoute is a function that returns the stored snapshots of a bot list.
+//
+//	@Summary		Get the history of a list the bot is on.
+//	@Description	Returns the snapshots periodically taken of the stats the bot list displays, such as its guild count, votes and rating, oldest first. Defaults to the last 30 days, only the most recent snapshots are returned when there are more than the limit.
+//	@tags			General
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=ListHistoryResponse}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			service			path		string	true	"The bot list service to get the history of."
+//	@Param			from			query		int		false	"Only include snapshots taken at or after this Unix timestamp in milliseconds."
+//	@Param			to				query		int		false	"Only include snapshots taken before this Unix timestamp in milliseconds."
+//	@Param			limit			query		int		false	"The maximum amount of snapshots to return."	minimum(1)	maximum(1000)	default(500)
+//
+//	@Router			/api/v1/services/{service}/history [get]
+func getServiceHistoryRoute(ctx *fiber.Ctx) error {
+	service := ctx.Params("service")
+	if !config.Has(fmt.Sprintf("services.%s", service)) {
+		msg := fmt.Sprintf("The service '%s' is not a valid service.", service)
+		return fiber.NewError(fiber.StatusBadRequest, msg)
+	}
+
+	filter := &ListHistoryQuery{Limit: 500}
+
+	if err := ctx.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*filter)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	to := time.Now().UTC()
+	if filter.To > 0 {
+		to = time.UnixMilli(filter.To).UTC()
+	}
+
+	from := to.AddDate(0, 0, -30)
+	if filter.From > 0 {
+		from = time.UnixMilli(filter.From).UTC()
+	}
+
+	snapshots, err := getListSnapshots(service, from, to, filter.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(
+		ListHistoryResponse{
+			Service:   service,
+			From:      from.UnixMilli(),
+			To:        to.UnixMilli(),
+			Snapshots: snapshots,
+		},
+		true,
+	))
+}
+
+// postVoteWebhookRoute is a function that receives vote webhooks sent by bot lists and persists the vote.
+//
+//	@Summary		Receive a vote webhook from a bot list.
+//	@Description	Bot lists call this route whenever a user votes. The request must be authorized with the webhook token set for the service, the voting user is pulled from the payload using the service's vote config and persisted to the database.
+//	@tags			Votes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=Vote}
+//	@Failure		400				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		401				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The webhook token set for the bot list"
+//
+//	@Param			service			path		string	true	"The bot list service sending the vote."
+//
+//	@Router			/api/webhooks/votes/{service} [post]
+func postVoteWebhookRoute(ctx *fiber.Ctx) error {
+	// The param is only valid until the handler returns, while the vote is emitted after it has.
+	service := utils.CopyString(ctx.Params("service"))
+
+	accepted := false
+	for _, s := range getVoteServices() {
+		if s == service {
+			accepted = true
+			break
+		}
+	}
+
+	if !accepted {
+		msg := fmt.Sprintf("The service '%s' does not accept vote webhooks.", service)
+		return fiber.NewError(fiber.StatusBadRequest, msg)
+	}
+
+	token := getServiceWebhookToken(service)
+	if token == "" || subtle.ConstantTimeCompare([]byte(ctx.Get(fiber.HeaderAuthorization)), []byte(token)) != 1 {
+		return fiber.NewError(fiber.StatusUnauthorized, "The webhook token is invalid.")
+	}
+
+	vote, err := parseVoteWebhook(service, ctx.Body())
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	insertErr := insertVote(vote)
+	if insertErr != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, insertErr.Error())
+	}
+
+	if reminderErr := scheduleVoteReminder(vote); reminderErr != nil {
+		log.Printf("Failed to schedule a vote reminder for %s on %s: %s", vote.UserId, vote.Service, reminderErr)
+	}
+
+	emitEventAsync("vote.received", vote)
+
+	return ctx.JSON(formJsonBody(vote, true))
+}
+
+// getVotesRoute is a function that returns the most recent votes received from bot lists.
+//
+//	@Summary		Get recent votes.
+//	@Description	Votes are returned most recent first and can be filtered by service, user and a time range. Results are paginated.
+//	@tags			Votes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=VotesResponse}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			service			query		string	false	"Only return votes from this bot list service."
+//	@Param			user			query		string	false	"Only return votes from this user."
+//	@Param			from			query		int		false	"Only return votes at or after this unix timestamp in milliseconds."
+//	@Param			to				query		int		false	"Only return votes before this unix timestamp in milliseconds."
+//	@Param			page			query		int		false	"The page of votes to return."		default(1)
+//	@Param			limit			query		int		false	"The amount of votes per page."		default(50)
+//
+//	@Router			/api/v1/votes [get]
+func getVotesRoute(ctx *fiber.Ctx) error {
+	filter := &VoteQuery{Page: 1, Limit: 50}
+
+	if err := ctx.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*filter)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	votes, total, err := getVotes(*filter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(
+		VotesResponse{
+			Votes: votes,
+			Page:  filter.Page,
+			Limit: filter.Limit,
+			Total: total,
+		},
+		true,
+	))
+}
+
+// getUserVoteStatusRoute is a function that returns whether a user has voted on each bot list.
+//
+//	@Summary		Get the vote status of a user.
+//	@Description	For every bot list accepting votes, this returns when the user last voted, whether they are still within the list's vote cooldown and when they are next able to vote. has_voted is true if the user is within the cooldown of any list.
+//	@tags			Votes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=UserVoteStatusResponse}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			user			path		string	true	"The user to get the vote status of."
+//
+//	@Router			/api/v1/votes/users/{user} [get]
+func getUserVoteStatusRoute(ctx *fiber.Ctx) error {
+	status, err := getUserVoteStatus(ctx.Params("user"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(status, true))
+}
+
+// getVoteLeaderboardRoute is a function that ranks the top voters over a period.
+//
+//	@Summary		Get the top voters over a period.
+//	@Description	Voters are ranked by points, where every vote is worth a point and weekend votes are worth the weekend multiplier set for the bot list they were cast on. Periods are aligned to UTC, an explicit from or to overrides the start or end of the period.
+//	@tags			Votes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=LeaderboardResponse}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			period			query		string	false	"The period to rank voters over."							default(month)	Enums(day, week, month, all)
+//	@Param			service			query		string	false	"Only count votes from this bot list service."
+//	@Param			from			query		int		false	"Override the start of the period with a unix timestamp in milliseconds."
+//	@Param			to				query		int		false	"Override the end of the period with a unix timestamp in milliseconds."
+//	@Param			limit			query		int		false	"The amount of voters to return."							default(10)
+//
+//	@Router			/api/v1/votes/leaderboard [get]
+func getVoteLeaderboardRoute(ctx *fiber.Ctx) error {
+	filter := &LeaderboardQuery{Period: "month", Limit: 10}
+
+	if err := ctx.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*filter)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	from, to := getLeaderboardRange(*filter, time.Now())
+	entries, err := getLeaderboard(filter.Service, from, to, filter.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(
+		LeaderboardResponse{
+			Period:  filter.Period,
+			Service: filter.Service,
+			From:    from.UnixMilli(),
+			To:      to.UnixMilli(),
+			Entries: entries,
+		},
+		true,
+	))
+}
+
+// getUserVoteStreakRoute is a function that returns the voting streaks of a user.
+//
+//	@Summary		Get the voting streaks of a user.
+//	@Description	The daily streak counts consecutive UTC days the user voted on any bot list. Each bot list also has a streak of consecutive cooldown windows voted, which breaks once the user goes twice the list's cooldown without voting.
+//	@tags			Votes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=UserVoteStreakResponse}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			user			path		string	true	"The user to get the voting streaks of."
+//
+//	@Router			/api/v1/votes/users/{user}/streak [get]
+func getUserVoteStreakRoute(ctx *fiber.Ctx) error {
+	streak, err := getUserVoteStreak(ctx.Params("user"))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(streak, true))
+}
+
+// postReminderSubscriptionRoute is a function that opts a user in to vote reminders.
+//
+//	@Summary		Opt a user in to vote reminders.
+//	@Description	Once opted in, every vote the user casts schedules a reminder for when the bot list's cooldown expires. The reminder is emitted as a vote.reminder event, delivered to the configured reminders URL and the event subscriptions listening for it. Reminders can be limited to specific bot lists, by default all lists accepting votes are included. Opting in again replaces the list of services.
+//	@tags			Votes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=ReminderSubscription}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string							true	"The required API key"
+//
+//	@Param			request			body		ReminderSubscriptionRequestBody	true	"The request body to pass in."
+//
+//	@Router			/api/v1/reminders [post]
+func postReminderSubscriptionRoute(ctx *fiber.Ctx) error {
+	body := new(ReminderSubscriptionRequestBody)
+
+	if err := ctx.BodyParser(body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*body)
+	voteServices := getVoteServices()
+	for i, service := range body.Services {
+		accepted := false
+		for _, s := range voteServices {
+			if s == service {
+				accepted = true
+				break
+			}
+		}
+
+		if !accepted {
+			errors = append(errors, &ErrorResponse{
+				FailedField: fmt.Sprintf("ReminderSubscriptionRequestBody.Services[%d]", i),
+				Tag:         "service",
+				Value:       service,
+			})
+		}
+	}
+
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	subscription, err := upsertReminderSubscription(*body)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(subscription, true))
+}
+
+// getReminderSubscriptionRoute is a function that returns a user's reminder subscription.
+//
+//	@Summary		Get a user's reminder subscription.
+//	@Description	Returns the bot lists the user is reminded to vote on as well as their reminders that have yet to be delivered.
+//	@tags			Votes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=ReminderSubscription}
+//	@Failure		404				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			user			path		string	true	"The user to get the reminder subscription of."
+//
+//	@Router			/api/v1/reminders/{user} [get]
+func getReminderSubscriptionRoute(ctx *fiber.Ctx) error {
+	user := ctx.Params("user")
+
+	subscription, err := getReminderSubscription(user)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if subscription == nil {
+		msg := fmt.Sprintf("The user '%s' has not opted in to reminders.", user)
+		return fiber.NewError(fiber.StatusNotFound, msg)
+	}
+
+	return ctx.JSON(formJsonBody(subscription, true))
+}
+
+// deleteReminderSubscriptionRoute is a function that opts a user out of vote reminders.
+//
+//	@Summary		Opt a user out of vote reminders.
+//	@Description	The user's subscription is removed along with any of their reminders that have yet to be delivered.
+//	@tags			Votes
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{}
+//	@Failure		404				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			user			path		string	true	"The user to opt out of reminders."
+//
+//	@Router			/api/v1/reminders/{user} [delete]
+func deleteReminderSubscriptionRoute(ctx *fiber.Ctx) error {
+	user := ctx.Params("user")
+
+	deleted, err := deleteReminderSubscription(user)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if !deleted {
+		msg := fmt.Sprintf("The user '%s' has not opted in to reminders.", user)
+		return fiber.NewError(fiber.StatusNotFound, msg)
+	}
+
+	return ctx.JSON(formJsonBody(nil, true))
+}
+
+// postSubscriptionRoute is a function that subscribes an endpoint to events.
+//
+//	@Summary		Subscribe an endpoint to events.
+//	@Description	Every event matching the subscription's event types is delivered to its URL as a POST request, an empty list of event types subscribes to all events. Deliveries are signed with an HMAC-SHA256 of the X-Lists-Timestamp header, a period and the body using the subscription's secret, sent in the X-Lists-Signature header. A secret is generated if one isn't passed in, it is only ever returned here. Failed deliveries are retried with backoff.
+//	@tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=Subscription}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string					true	"The required API key"
+//
+//	@Param			request			body		SubscriptionRequestBody	true	"The request body to pass in."
+//
+//	@Router			/api/v1/subscriptions [post]
+func postSubscriptionRoute(ctx *fiber.Ctx) error {
+	body := new(SubscriptionRequestBody)
+
+	if err := ctx.BodyParser(body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*body)
+	for i, eventType := range body.Events {
+		if !isEventType(eventType) {
+			errors = append(errors, &ErrorResponse{
+				FailedField: fmt.Sprintf("SubscriptionRequestBody.Events[%d]", i),
+				Tag:         "event",
+				Value:       eventType,
+			})
+		}
+	}
+
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	subscription, err := insertSubscription(*body)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(subscription, true))
+}
+
+// getSubscriptionsRoute is a function that returns all event subscriptions.
+//
+//	@Summary		Get all event subscriptions.
+//	@Description	Returns every subscription without its secret.
+//	@tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=[]Subscription}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Router			/api/v1/subscriptions [get]
+func getSubscriptionsRoute(ctx *fiber.Ctx) error {
+	subscriptions, err := getSubscriptions()
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(subscriptions, true))
+}
+
+// getSubscriptionRoute is a function that returns a single event subscription.
+//
+//	@Summary		Get an event subscription.
+//	@Description	Returns the subscription without its secret.
+//	@tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=Subscription}
+//	@Failure		400				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		404				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			id				path		int		true	"The id of the subscription."
+//
+//	@Router			/api/v1/subscriptions/{id} [get]
+func getSubscriptionRoute(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "The subscription id must be a number.")
+	}
+
+	subscription, subscriptionErr := getSubscription(int64(id))
+	if subscriptionErr != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, subscriptionErr.Error())
+	}
+
+	if subscription == nil {
+		msg := fmt.Sprintf("The subscription '%d' does not exist.", id)
+		return fiber.NewError(fiber.StatusNotFound, msg)
+	}
+
+	return ctx.JSON(formJsonBody(subscription, true))
+}
+
+// deleteSubscriptionRoute is a function that deletes an event subscription.
+//
+//	@Summary		Delete an event subscription.
+//	@Description	The subscription is deleted along with its delivery log, pending deliveries are dropped.
+//	@tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{}
+//	@Failure		400				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		404				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			id				path		int		true	"The id of the subscription."
+//
+//	@Router			/api/v1/subscriptions/{id} [delete]
+func deleteSubscriptionRoute(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "The subscription id must be a number.")
+	}
+
+	deleted, deleteErr := deleteSubscription(int64(id))
+	if deleteErr != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, deleteErr.Error())
+	}
+
+	if !deleted {
+		msg := fmt.Sprintf("The subscription '%d' does not exist.", id)
+		return fiber.NewError(fiber.StatusNotFound, msg)
+	}
+
+	return ctx.JSON(formJsonBody(nil, true))
+}
+
+// getDeliveriesRoute is a function that returns the delivery log of an event subscription.
+//
+//	@Summary		Get the delivery log of an event subscription.
+//	@Description	Deliveries are returned most recent first along with the status code the subscriber responded with and the last error. Results are paginated.
+//	@tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=DeliveriesResponse}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			id				path		int		true	"The id of the subscription."
+//	@Param			status			query		string	false	"Only return deliveries with this status."	Enums(pending, delivered, failed)
+//	@Param			page			query		int		false	"The page of deliveries to return."			default(1)
+//	@Param			limit			query		int		false	"The amount of deliveries per page."		default(50)
+//
+//	@Router			/api/v1/subscriptions/{id}/deliveries [get]
+func getDeliveriesRoute(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "The subscription id must be a number.")
+	}
+
+	filter := &DeliveryQuery{Page: 1, Limit: 50}
+
+	if parseErr := ctx.QueryParser(filter); parseErr != nil {
+		return fiber.NewError(fiber.StatusBadRequest, parseErr.Error())
+	}
+
+	errors := validateStruct(*filter)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	deliveries, total, deliveriesErr := getDeliveries(int64(id), *filter)
+	if deliveriesErr != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, deliveriesErr.Error())
+	}
+
+	return ctx.JSON(formJsonBody(
+		DeliveriesResponse{
+			Deliveries: deliveries,
+			Page:       filter.Page,
+			Limit:      filter.Limit,
+			Total:      total,
+		},
+		true,
+	))
+}
+
+// postRedeliveryRoute is a function that redelivers an event to a subscriber.
+//
+//	@Summary		Redeliver an event.
+//	@Description	A new delivery of the same event is queued for the same subscription, the original delivery stays in the log.
+//	@tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=EventDelivery}
+//	@Failure		400				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		404				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			id				path		int		true	"The id of the delivery to redeliver."
+//
+//	@Router			/api/v1/deliveries/{id}/redeliver [post]
+func postRedeliveryRoute(ctx *fiber.Ctx) error {
+	id, err := ctx.ParamsInt("id")
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "The delivery id must be a number.")
+	}
+
+	delivery, redeliveryErr := redeliver(int64(id))
+	if redeliveryErr != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, redeliveryErr.Error())
+	}
+
+	if delivery == nil {
+		msg := fmt.Sprintf("The delivery '%d' does not exist.", id)
+		return fiber.NewError(fiber.StatusNotFound, msg)
+	}
+
+	return ctx.JSON(formJsonBody(delivery, true))
+}
+
+// getEventStreamRoute is a function that streams events as they are emitted.
+//
+//	@Summary		Stream events in real time.
+//	@Description	Streams every event matching the event types as Server-Sent Events, an empty list of event types streams all events. Each event's id is sent along with it, reconnecting with the Last-Event-ID header replays the events missed in between from the event log. Heartbeat comments are sent while no events are emitted.
+//	@tags			Events
+//	@Accept			json
+//	@Produce		text/event-stream
+//	@Success		200				{object}	Event
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string		true	"The required API key"
+//	@Param			Last-Event-ID	header		int			false	"The id of the last event received, to resume from."
+//	@Param			events			query		[]string	false	"The event types to stream."	collectionFormat(csv)
+//
+//	@Router			/api/v1/events/stream [get]
+func getEventStreamRoute(ctx *fiber.Ctx) error {
+	query := new(EventStreamQuery)
+
+	if err := ctx.QueryParser(query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*query)
+	for i, eventType := range query.Events {
+		if !isEventType(eventType) {
+			errors = append(errors, &ErrorResponse{
+				FailedField: fmt.Sprintf("EventStreamQuery.Events[%d]", i),
+				Tag:         "event",
+				Value:       eventType,
+			})
+		}
+	}
+
+	var lastId int64
+	if header := ctx.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || id < 0 {
+			errors = append(errors, &ErrorResponse{FailedField: "Last-Event-ID", Tag: "min=0", Value: header})
+		}
+
+		lastId = id
+	}
+
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	listener := broker.subscribe()
+
+	var replay []Event
+	pageSize := config.GetDefault("events.stream.replay_page_size", int64(500)).(int64)
+	if lastId > 0 {
+		events, err := getEventsAfter(lastId, query.Events, pageSize)
+		if err != nil {
+			broker.unsubscribe(listener)
+			return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+		}
+
+		replay = events
+	}
+
+	ctx.Set(fiber.HeaderContentType, "text/event-stream")
+	ctx.Set(fiber.HeaderCacheControl, "no-cache")
+	ctx.Set(fiber.HeaderConnection, "keep-alive")
+	ctx.Set("X-Accel-Buffering", "no")
+
+	ctx.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		streamEvents(w, listener, replay, pageSize, lastId, query.Events)
+	})
+
+	return nil
+}
+
+// getPublicGuildCountRoute is a function that publicly returns the latest guild count.
+//
+//	@Summary		Get the latest guild count.
+//	@Description	Returns the most recently posted guild count and when it was posted. This route doesn't require an API key, the data is cached.
+//	@tags			Public
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	ResponseHTTP{data=PublicGuildCountResponse}
+//	@Failure		429	{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		503	{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Router			/api/public/guilds [get]
+func getPublicGuildCountRoute(ctx *fiber.Ctx) error {
+	response, err := publicGuildCount.get()
+	if err != nil {
+		log.Printf("Failed to get the public guild count: %s", err)
+		return fiber.NewError(fiber.StatusServiceUnavailable, "The guild count is currently unavailable.")
+	}
+
+	setPublicCacheControl(ctx)
+
+	return ctx.JSON(formJsonBody(response, true))
+}
+
+// getPublicServicesRoute is a function that publicly returns the bot lists the bot is on.
+//
+//	@Summary		Get all active lists the bot is on.
+//	@Description	Returns the links to every active bot list and the guild count each list displays. This route doesn't require an API key, the data is cached.
+//	@tags			Public
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	ResponseHTTP{data=[]PublicServiceResponse}
+//	@Failure		429	{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		503	{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Router			/api/public/services [get]
+func getPublicServicesRoute(ctx *fiber.Ctx) error {
+	responses, _, errors := servicesCache.get(false)
+	if len(errors) > 0 {
+		for _, err := range errors {
+			log.Printf("Failed to get the public services: %s", err)
+		}
+
+		return fiber.NewError(fiber.StatusServiceUnavailable, "The bot lists are currently unavailable.")
+	}
+
+	services := make([]PublicServiceResponse, 0)
+	for _, response := range responses {
+		service := PublicServiceResponse{
+			ShortName:  response.ShortName,
+			LongName:   getServiceConfig(response.ShortName).LongName,
+			Url:        response.Url,
+			GuildCount: response.GuildCount,
+		}
+
+		if voteConfig := getServiceVoteConfig(response.ShortName); voteConfig.Enabled {
+			service.VoteUrl = voteConfig.Url
+		}
+
+		services = append(services, service)
+	}
+
+	sort.Slice(services, func(i, j int) bool { return services[i].ShortName < services[j].ShortName })
+
+	setPublicCacheControl(ctx)
+
+	return ctx.JSON(formJsonBody(services, true))
+}
+
+// getServersBadgeRoute is a function that publicly renders the latest guild count as a badge.
+//
+//	@Summary		Get a guild count badge.
+//	@Description	Renders the most recently posted guild count as an SVG badge. This route doesn't require an API key, the badge is cached.
+//	@tags			Public
+//	@Accept			json
+//	@Produce		image/svg+xml
+//	@Param			label	query		string	false	"The text on the left of the badge."	default(servers)
+//	@Param			color	query		string	false	"A named color or a hex color without the #."
+//	@Param			format	query		string	false	"Whether to compact the number."	Enums(compact, full)	default(compact)
+//	@Success		200		{string}	string
+//	@Failure		400		{object}	ResponseHTTPError{data=[]ErrorResponse}
+//	@Failure		429		{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Router			/api/public/badges/servers [get]
+func getServersBadgeRoute(ctx *fiber.Ctx) error {
+	query := &BadgeQuery{Label: "servers", Format: "compact"}
+
+	if err := ctx.QueryParser(query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*query)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	response, err := publicGuildCount.get()
+	if err != nil {
+		log.Printf("Failed to get the guild count for the badge: %s", err)
+		return sendSvg(ctx, renderBadge(query.Label, "unavailable", badgeColors["grey"]), false)
+	}
+
+	color := resolveBadgeColor(query.Color, getDefaultBadgeColor())
+	value := formatBadgeNumber(response.GuildCount, query.Format)
+
+	return sendSvg(ctx, renderBadge(query.Label, value, color), true)
+}
+
+// getVotesBadgeRoute is a function that publicly renders the votes a bot list received as a badge.
+//
+//	@Summary		Get a vote count badge.
+//	@Description	Renders the amount of votes received on the bot list within the period as an SVG badge. The monthly and all time votes are those displayed on the list, while votes for the day and week are counted from the vote webhooks received, as is any period the list doesn't report. This route doesn't require an API key, the badge is cached.
+//	@tags			Public
+//	@Accept			json
+//	@Produce		image/svg+xml
+//	@Param			service	path		string	true	"The service to count the votes of."
+//	@Param			label	query		string	false	"The text on the left of the badge."	default(votes)
+//	@Param			color	query		string	false	"A named color or a hex color without the #."
+//	@Param			format	query		string	false	"Whether to compact the number."	Enums(compact, full)	default(compact)
+//	@Param			period	query		string	false	"The period to count the votes in."	Enums(day, week, month, all)	default(month)
+//	@Success		200		{string}	string
+//	@Failure		400		{object}	ResponseHTTPError{data=[]ErrorResponse}
+//	@Failure		404		{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		429		{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Router			/api/public/badges/votes/{service} [get]
+func getVotesBadgeRoute(ctx *fiber.Ctx) error {
+	service := ctx.Params("service")
+	if !isServiceName(service) {
+		msg := fmt.Sprintf("The service '%s' does not exist.", service)
+		return fiber.NewError(fiber.StatusNotFound, msg)
+	}
+
+	if !getServiceVoteConfig(service).Enabled {
+		msg := fmt.Sprintf("The service '%s' does not accept vote webhooks.", service)
+		return fiber.NewError(fiber.StatusBadRequest, msg)
+	}
+
+	query := &VoteBadgeQuery{Label: "votes", Format: "compact", Period: "month"}
+
+	if err := ctx.QueryParser(query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*query)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	count, err := getListVoteCount(service, query.Period, time.Now())
+	if err != nil {
+		log.Printf("Failed to count the votes on %s for the badge: %s", service, err)
+		return sendSvg(ctx, renderBadge(query.Label, "unavailable", badgeColors["grey"]), false)
+	}
+
+	color := resolveBadgeColor(query.Color, getDefaultBadgeColor())
+	value := formatBadgeNumber(count, query.Format)
+
+	return sendSvg(ctx, renderBadge(query.Label, value, color), true)
+}
+
+// getGrowthSparklineRoute is a function that publicly renders the recent guild count growth as a sparkline.
+//
+//	@Summary		Get a guild count growth sparkline.
+//	@Description	Renders the daily closing guild counts over the last days as an SVG sparkline. Days before the first post are left out. This route doesn't require an API key, the sparkline is cached.
+//	@tags			Public
+//	@Accept			json
+//	@Produce		image/svg+xml
+//	@Param			days	query		int		false	"The amount of days to render."	minimum(2)	maximum(90)	default(30)
+//	@Param			width	query		int		false	"The width of the sparkline."	minimum(20)	maximum(1000)	default(120)
+//	@Param			height	query		int		false	"The height of the sparkline."	minimum(10)	maximum(200)	default(20)
+//	@Param			color	query		string	false	"A named color or a hex color without the #."
+//	@Success		200		{string}	string
+//	@Failure		400		{object}	ResponseHTTPError{data=[]ErrorResponse}
+//	@Failure		429		{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Router			/api/public/badges/growth [get]
+func getGrowthSparklineRoute(ctx *fiber.Ctx) error {
+	query := &SparklineQuery{Days: 30, Width: 120, Height: 20}
+
+	if err := ctx.QueryParser(query); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*query)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	color := resolveBadgeColor(query.Color, getDefaultBadgeColor())
+
+	now := time.Now().UTC()
+	points, err := getGuildCountHistory(now.AddDate(0, 0, -query.Days))
+	if err != nil {
+		log.Printf("Failed to get the guild count history for the sparkline: %s", err)
+		return sendSvg(ctx, renderSparkline(nil, query.Width, query.Height, color), false)
+	}
+
+	var closes []int64
+	for _, day := range calculateDailyGrowth(points, query.Days, 1, now) {
+		if day.Close > 0 {
+			closes = append(closes, day.Close)
+		}
+	}
+
+	return sendSvg(ctx, renderSparkline(closes, query.Width, query.Height, color), true)
+}
+
+// getTokensCheckRoute is a function that checks the token of every active bot list.
+//
+//	@Summary		Check the tokens of all active lists.
+//	@Description	Sends a harmless authenticated request to every active bot list and reports whether its token is missing, invalid or accepted. Each list is checked against its token_check_url, an endpoint which rejects bad tokens, and lists without one are reported as unknown.
+//	@tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=[]TokenCheckResult}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Router			/api/v1/admin/tokens [get]
+func getTokensCheckRoute(ctx *fiber.Ctx) error {
+	return ctx.JSON(formJsonBody(checkServiceTokens(), true))
+}
+
+// getAdminServicesRoute is a function that returns whether every configured bot list is active.
+//
+//	@Summary		Get the status of all configured lists.
+//	@Description	Returns whether every bot list in the config is enabled there, whether it is currently active and the override set through the admin routes, if any. Overrides take precedence over the config.
+//	@tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=[]AdminServiceStatus}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Router			/api/v1/admin/services [get]
+func getAdminServicesRoute(ctx *fiber.Ctx) error {
+	overrides := serviceOverrides.get()
+	now := time.Now()
+
+	services := config.Get("services").(*toml.Tree).Keys()
+	sort.Strings(services)
+
+	statuses := make([]AdminServiceStatus, 0)
+	for _, service := range services {
+		enabled, _ := config.Get(fmt.Sprintf("services.%s.enabled", service)).(bool)
+		status := AdminServiceStatus{
+			Service:       service,
+			ConfigEnabled: enabled,
+			Active:        isServiceActive(service, enabled, overrides, now),
+		}
+
+		if override, ok := overrides[service]; ok {
+			status.Override = &override
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return ctx.JSON(formJsonBody(statuses, true))
+}
+
+// postServiceEnableRoute is a function that enables a bot list regardless of the config.
+//
+//	@Summary		Enable a list.
+//	@Description	Enables the bot list regardless of its enabled flag in the config, lifting any pause. The change is recorded in the service's audit log.
+//	@tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=ServiceOverride}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string						true	"The required API key"
+//
+//	@Param			service			path		string						true	"The bot list service to enable."
+//	@Param			request			body		ServiceOverrideRequestBody	false	"The request body to pass in."
+//
+//	@Router			/api/v1/admin/services/{service}/enable [post]
+func postServiceEnableRoute(ctx *fiber.Ctx) error {
+	return overrideServiceEnabled(ctx, true)
+}
+
+// postServiceDisableRoute is a function that disables a bot list regardless of the config.
+//
+//	@Summary		Disable a list.
+//	@Description	Disables the bot list regardless of its enabled flag in the config, lifting any pause. Stats aren't posted to or fetched from disabled lists. The change is recorded in the service's audit log.
+//	@tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=ServiceOverride}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string						true	"The required API key"
+//
+//	@Param			service			path		string						true	"The bot list service to disable."
+//	@Param			request			body		ServiceOverrideRequestBody	false	"The request body to pass in."
+//
+//	@Router			/api/v1/admin/services/{service}/disable [post]
+func postServiceDisableRoute(ctx *fiber.Ctx) error {
+	return overrideServiceEnabled(ctx, false)
+}
+
+func overrideServiceEnabled(ctx *fiber.Ctx, enabled bool) error {
+	service := ctx.Params("service")
+	if !config.Has(fmt.Sprintf("services.%s", service)) {
+		msg := fmt.Sprintf("The service '%s' is not a valid service.", service)
+		return fiber.NewError(fiber.StatusBadRequest, msg)
+	}
+
+	body := new(ServiceOverrideRequestBody)
+
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	}
+
+	errors := validateStruct(*body)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	action := "disabled"
+	if enabled {
+		action = "enabled"
+	}
+
+	override, err := setServiceOverride(service, action, &enabled, nil, body.Reason, getApiKeyName(ctx))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	go servicesCache.revalidate()
+
+	return ctx.JSON(formJsonBody(override, true))
+}
+
+// postServicePauseRoute is a function that pauses a bot list until a given time.
+//
+//	@Summary		Pause a list.
+//	@Description	Pauses the bot list either until the given Unix timestamp in milliseconds or for the given duration, such as 2h. The list is inactive while paused and becomes active again on its own afterwards. The change is recorded in the service's audit log.
+//	@tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=ServiceOverride}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string					true	"The required API key"
+//
+//	@Param			service			path		string					true	"The bot list service to pause."
+//	@Param			request			body		ServicePauseRequestBody	true	"The request body to pass in."
+//
+//	@Router			/api/v1/admin/services/{service}/pause [post]
+func postServicePauseRoute(ctx *fiber.Ctx) error {
+	service := ctx.Params("service")
+	if !config.Has(fmt.Sprintf("services.%s", service)) {
+		msg := fmt.Sprintf("The service '%s' is not a valid service.", service)
+		return fiber.NewError(fiber.StatusBadRequest, msg)
+	}
+
+	body := new(ServicePauseRequestBody)
+
+	if err := ctx.BodyParser(body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*body)
+
+	now := time.Now().UTC()
+	until := time.UnixMilli(body.Until).UTC()
+	if body.Duration != "" {
+		duration, err := time.ParseDuration(body.Duration)
+		if err != nil || duration <= 0 {
+			errors = append(errors, &ErrorResponse{
+				FailedField: "ServicePauseRequestBody.Duration",
+				Tag:         "duration",
+				Value:       body.Duration,
+			})
+		}
+
+		until = now.Add(duration)
+	} else if body.Until > 0 && !until.After(now) {
+		errors = append(errors, &ErrorResponse{
+			FailedField: "ServicePauseRequestBody.Until",
+			Tag:         "future",
+			Value:       fmt.Sprint(body.Until),
+		})
+	}
+
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	override, err := setServiceOverride(service, "paused", nil, &until, body.Reason, getApiKeyName(ctx))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	go servicesCache.revalidate()
+
+	return ctx.JSON(formJsonBody(override, true))
+}
+
+// deleteServiceOverrideRoute is a function that removes the override of a bot list.
+//
+//	@Summary		Reset a list to its config.
+//	@Description	Removes the override set through the admin routes, so that whether the bot list is active follows its enabled flag in the config again. The change is recorded in the service's audit log.
+//	@tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		404				{object}	ResponseHTTPError{data=DefaultFiberError}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string						true	"The required API key"
+//
+//	@Param			service			path		string						true	"The bot list service to reset."
+//	@Param			request			body		ServiceOverrideRequestBody	false	"The request body to pass in."
+//
+//	@Router			/api/v1/admin/services/{service}/override [delete]
+func deleteServiceOverrideRoute(ctx *fiber.Ctx) error {
+	service := ctx.Params("service")
+	if !config.Has(fmt.Sprintf("services.%s", service)) {
+		msg := fmt.Sprintf("The service '%s' is not a valid service.", service)
+		return fiber.NewError(fiber.StatusBadRequest, msg)
+	}
+
+	body := new(ServiceOverrideRequestBody)
+
+	if len(ctx.Body()) > 0 {
+		if err := ctx.BodyParser(body); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	}
+
+	errors := validateStruct(*body)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	deleted, err := deleteServiceOverride(service, body.Reason, getApiKeyName(ctx))
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	if !deleted {
+		msg := fmt.Sprintf("The service '%s' doesn't have an override.", service)
+		return fiber.NewError(fiber.StatusNotFound, msg)
+	}
+
+	go servicesCache.revalidate()
+
+	return ctx.JSON(formJsonBody(nil, true))
+}
+
+// getServiceOverrideAuditRoute is a function that returns the changes made to the override of a bot list.
+//
+//	@Summary		Get the audit log of a list.
+//	@Description	Returns which API key enabled, disabled, paused or reset the bot list through the admin routes and when, most recent first.
+//	@tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=[]ServiceOverrideAuditEntry}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			service			path		string	true	"The bot list service to get the audit log of."
+//	@Param			limit			query		int		false	"The maximum amount of entries to return."	minimum(1)	maximum(100)	default(50)
+//
+//	@Router			/api/v1/admin/services/{service}/audit [get]
+func getServiceOverrideAuditRoute(ctx *fiber.Ctx) error {
+	service := ctx.Params("service")
+	if !config.Has(fmt.Sprintf("services.%s", service)) {
+		msg := fmt.Sprintf("The service '%s' is not a valid service.", service)
+		return fiber.NewError(fiber.StatusBadRequest, msg)
+	}
+
+	filter := &OverrideAuditQuery{Limit: 50}
+
+	if err := ctx.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*filter)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	entries, err := getServiceOverrideAudit(service, filter.Limit)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(entries, true))
+}
+
+// getAuditLogRoute is a function that returns the audit log of mutating requests.
+//
+//	@Summary		Get the audit log.
+//	@Description	Returns every mutating request made with an API key, most recent first. Each entry includes the name of the key, the IP, the route, the request body with secrets redacted and the result.
+//	@tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Success		200				{object}	ResponseHTTP{data=AuditLogResponse}
+//	@Failure		400				{object}	ResponseHTTPError{}
+//	@Failure		500				{object}	ResponseHTTPError{data=DefaultFiberError}
+//
+//	@Param			Authorization	header		string	true	"The required API key"
+//
+//	@Param			key				query		string	false	"Only include requests made with the API key with this name."
+//	@Param			method			query		string	false	"Only include requests with this method."	Enums(POST, PUT, PATCH, DELETE)
+//	@Param			route			query		string	false	"Only include requests to this route, such as /api/v1/subscriptions/:id."
+//	@Param			status			query		int		false	"Only include requests that resulted in this status code."
+//	@Param			from			query		int		false	"Only include requests made at or after this Unix timestamp in milliseconds."
+//	@Param			to				query		int		false	"Only include requests made before this Unix timestamp in milliseconds."
+//	@Param			page			query		int		false	"The page to return."	minimum(1)	default(1)
+//	@Param			limit			query		int		false	"The amount of entries per page."	minimum(1)	maximum(100)	default(50)
+//
+//	@Router			/api/v1/admin/audit [get]
+func getAuditLogRoute(ctx *fiber.Ctx) error {
+	filter := &AuditQuery{Page: 1, Limit: 50}
+
+	if err := ctx.QueryParser(filter); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+
+	errors := validateStruct(*filter)
+	if errors != nil {
+		return ctx.Status(fiber.StatusBadRequest).JSON(formJsonBody(errors, false))
+	}
+
+	entries, total, err := getAuditEntries(*filter)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, err.Error())
+	}
+
+	return ctx.JSON(formJsonBody(
+		AuditLogResponse{
+			Entries: entries,
+			Page:    filter.Page,
+			Limit:   filter.Limit,
+			Total:   total,
+		},
+		true,
+	))
+}
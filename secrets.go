@@ -0,0 +1,162 @@
+package main
+
+import (
+	"github.com/pelletier/go-toml"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// secretFileTtl is how long secrets read from files are cached for, so that rotated secrets are picked up without
+// reading the file on every request.
+const secretFileTtl = time.Minute
+
+// minimumSecretLength stops short secrets from being redacted, as they would redact unrelated text along with them.
+const minimumSecretLength = 6
+
+type cachedSecret struct {
+	value    string
+	loadedAt time.Time
+}
+
+// secretLog logs failures to read secrets. It can't be the standard logger, as its output reads the secrets to redact
+// them while holding the logger's lock.
+var secretLog = log.New(os.Stderr, "", log.LstdFlags)
+
+var secretFiles = struct {
+	mutex   sync.Mutex
+	secrets map[string]cachedSecret
+}{secrets: make(map[string]cachedSecret)}
+
+// getSecret returns the secret set in the environment variable. When the variable isn't set, the secret is read from
+// the file at the path set in the variable suffixed with _FILE instead, such as a Docker or Kubernetes secret.
+func getSecret(name string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return ""
+	}
+
+	secretFiles.mutex.Lock()
+	cached, ok := secretFiles.secrets[path]
+	if ok && time.Since(cached.loadedAt) < secretFileTtl {
+		secretFiles.mutex.Unlock()
+		return cached.value
+	}
+
+	// A failed read keeps the previous value until the file is retried.
+	contents, err := os.ReadFile(path)
+	if err == nil {
+		cached.value = strings.TrimSpace(string(contents))
+	}
+
+	cached.loadedAt = time.Now()
+	secretFiles.secrets[path] = cached
+	secretFiles.mutex.Unlock()
+
+	if err != nil {
+		secretLog.Printf("Failed to read the secret %s from its file: %s", name, err)
+	}
+
+	return cached.value
+}
+
+var knownSecrets = struct {
+	mutex    sync.Mutex
+	secrets  []string
+	loadedAt time.Time
+}{}
+
+// getKnownSecrets returns every secret currently set, longest first, along with the forms they take when escaped in
+// a URL. Secrets are redacted from every log line and error, so the list is only rebuilt once the secrets read from
+// files may have changed. The tokens of services are only known once the config has been loaded, so the list isn't
+// kept until then.
+func getKnownSecrets() []string {
+	knownSecrets.mutex.Lock()
+	defer knownSecrets.mutex.Unlock()
+
+	if knownSecrets.secrets != nil && time.Since(knownSecrets.loadedAt) < secretFileTtl {
+		return knownSecrets.secrets
+	}
+
+	known := loadKnownSecrets()
+	if config != nil {
+		knownSecrets.secrets = known
+		knownSecrets.loadedAt = time.Now()
+	}
+
+	return known
+}
+
+func loadKnownSecrets() []string {
+	secrets := []string{getSecret("REMINDERS_WEBHOOK_TOKEN"), getSecret("DISCORD_WEBHOOK_URL")}
+
+	for _, key := range getApiKeys() {
+		secrets = append(secrets, key)
+	}
+
+	if postgresUrl := getSecret("POSTGRES_URL"); postgresUrl != "" {
+		secrets = append(secrets, postgresUrl)
+		if parsed, err := url.Parse(postgresUrl); err == nil && parsed.User != nil {
+			password, _ := parsed.User.Password()
+			secrets = append(secrets, password)
+		}
+	}
+
+	if config != nil {
+		if services, ok := config.Get("services").(*toml.Tree); ok {
+			for _, service := range services.Keys() {
+				secrets = append(secrets, getServiceToken(service), getServiceWebhookToken(service))
+			}
+		}
+	}
+
+	known := make([]string, 0)
+	for _, secret := range secrets {
+		if len(secret) < minimumSecretLength {
+			continue
+		}
+
+		known = append(known, secret)
+		for _, escaped := range []string{url.QueryEscape(secret), url.PathEscape(secret)} {
+			if escaped != secret {
+				known = append(known, escaped)
+			}
+		}
+	}
+
+	sort.Slice(known, func(i, j int) bool { return len(known[i]) > len(known[j]) })
+
+	return known
+}
+
+// redactSecrets replaces every known secret in the text, so that text which may contain secrets, such as the error of
+// a failed request to a URL with a token in it, can be logged or returned.
+func redactSecrets(text string) string {
+	for _, secret := range getKnownSecrets() {
+		text = strings.ReplaceAll(text, secret, redactedValue)
+	}
+
+	return text
+}
+
+// redactingWriter redacts secrets from everything written through it, used as the output of the loggers.
+type redactingWriter struct {
+	writer io.Writer
+}
+
+func (w *redactingWriter) Write(p []byte) (int, error) {
+	if _, err := io.WriteString(w.writer, redactSecrets(string(p))); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
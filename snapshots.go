@@ -0,0 +1,93 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// startSnapshotter periodically stores the stats every active list displays, so that trends such as votes can be
+// charted per list over time.
+func startSnapshotter() {
+	if !config.GetDefault("snapshots.enabled", false).(bool) {
+		return
+	}
+
+	interval := getConfigDuration("snapshots.interval", time.Hour)
+
+	go func() {
+		for {
+			snapshotServices(interval)
+			time.Sleep(interval)
+		}
+	}()
+}
+
+// snapshotServices fetches fresh data from every active list, which also refreshes the service data cache, and stores
+// a snapshot for each list that responded. Lists that were already snapshotted within half the interval are skipped
+// so that running multiple instances doesn't store duplicates.
+func snapshotServices(interval time.Duration) {
+	responses, errors := servicesCache.refresh()
+	for _, err := range errors {
+		log.Printf("Failed to fetch a list for its snapshot: %s", err)
+	}
+
+	for _, response := range responses {
+		if response.Error {
+			continue
+		}
+
+		if err := insertListSnapshot(response, interval/2); err != nil {
+			log.Printf("Failed to store the snapshot of %s: %s", response.ShortName, err)
+		}
+	}
+}
+
+func insertListSnapshot(response BotListServiceResponse, minimumGap time.Duration) error {
+	query := `insert into list_snapshots(service, guild_count, shard_count, votes, monthly_votes, rank, rating)
+		select $1, $2, $3, $4, $5, $6, $7
+		where not exists (
+			select 1 from list_snapshots
+			where service = $1 and created_at > (now() at time zone ('utc')) - make_interval(secs => $8)
+		)`
+	_, err := execQuery(
+		query,
+		response.ShortName, response.GuildCount, response.ShardCount, response.Votes, response.MonthlyVotes,
+		response.Rank, response.Rating, minimumGap.Seconds(),
+	)
+
+	return err
+}
+
+// getListSnapshots returns the service's snapshots within the time range, oldest first. When there are more than the
+// limit, the most recent ones are returned.
+func getListSnapshots(service string, from time.Time, to time.Time, limit int64) ([]ListSnapshot, error) {
+	query := `select * from (
+			select guild_count, shard_count, votes, monthly_votes, rank, rating, created_at from list_snapshots
+			where service = $1 and created_at >= $2 and created_at < $3
+			order by created_at desc limit $4
+		) as snapshots order by created_at`
+	rows, err := queryRows(query, service, from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	snapshots := make([]ListSnapshot, 0)
+	for rows.Next() {
+		var snapshot ListSnapshot
+		var createdAt time.Time
+		scanErr := rows.Scan(
+			&snapshot.GuildCount, &snapshot.ShardCount, &snapshot.Votes, &snapshot.MonthlyVotes, &snapshot.Rank,
+			&snapshot.Rating, &createdAt,
+		)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+
+		snapshot.Timestamp = createdAt.UnixMilli()
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// eventBroker fans out emitted events to the connected event streams of this instance. Streams that fall behind are
+// closed rather than blocking the emitter, clients then reconnect and catch up from the event log.
+type eventBroker struct {
+	mutex     sync.Mutex
+	listeners map[chan Event]bool
+}
+
+var broker = &eventBroker{listeners: make(map[chan Event]bool)}
+
+func (b *eventBroker) subscribe() chan Event {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	listener := make(chan Event, 64)
+	b.listeners[listener] = true
+
+	return listener
+}
+
+func (b *eventBroker) unsubscribe(listener chan Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.listeners[listener] {
+		delete(b.listeners, listener)
+		close(listener)
+	}
+}
+
+func (b *eventBroker) publish(event Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for listener := range b.listeners {
+		select {
+		case listener <- event:
+		default:
+			delete(b.listeners, listener)
+			close(listener)
+		}
+	}
+}
+
+// getEventsAfter returns a page of the persisted events after the given id in the order they were emitted, used to
+// replay the events a stream missed while disconnected. Events of any type are returned when no types are given.
+func getEventsAfter(id int64, types []string, limit int64) ([]Event, error) {
+	query := `select id, type, payload, created_at from events
+		where id > $1 and ($2::text[] is null or cardinality($2::text[]) = 0 or type = any($2))
+		order by id limit $3`
+	rows, err := queryRows(query, id, types, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var event Event
+		var payload string
+		var createdAt time.Time
+		if scanErr := rows.Scan(&event.Id, &event.Type, &payload, &createdAt); scanErr != nil {
+			return nil, scanErr
+		}
+
+		event.Data = json.RawMessage(payload)
+		event.Timestamp = createdAt.UnixMilli()
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// writeStreamEvent writes the event in the Server-Sent Events format, with the event id allowing clients to resume
+// from it using the Last-Event-ID header.
+func writeStreamEvent(w *bufio.Writer, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
+
+// streamEvents writes the replayed events followed by every event published to the listener until the client
+// disconnects or falls behind. The replay starts with the first page of missed events, and the following pages are
+// read until every missed event was replayed. The listener is subscribed before replaying, so events emitted while
+// replaying are skipped when they were already replayed. Event ids are allocated before the event is committed, so an
+// event can be published after one with a higher id, which is why the replayed ids are tracked rather than only the
+// last one. Heartbeats are sent while idle so that disconnects are noticed and proxies keep the connection open.
+func streamEvents(w *bufio.Writer, listener chan Event, replay []Event, pageSize int64, lastId int64, types []string) {
+	defer broker.unsubscribe(listener)
+
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", getConfigDuration("events.stream.retry", time.Second*5).Milliseconds()); err != nil {
+		return
+	}
+
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	replayed := make(map[int64]bool)
+	for len(replay) > 0 {
+		for _, event := range replay {
+			if err := writeStreamEvent(w, event); err != nil {
+				return
+			}
+
+			replayed[event.Id] = true
+			lastId = event.Id
+		}
+
+		if int64(len(replay)) < pageSize {
+			break
+		}
+
+		page, err := getEventsAfter(lastId, types, pageSize)
+		if err != nil {
+			// The client resumes the replay from the last event it received when it reconnects.
+			log.Printf("Failed to replay the events after %d to a stream: %s", lastId, err)
+			return
+		}
+
+		replay = page
+	}
+
+	heartbeat := time.NewTicker(getConfigDuration("events.stream.heartbeat", time.Second*15))
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-listener:
+			if !ok {
+				return
+			}
+
+			if replayed[event.Id] {
+				delete(replayed, event.Id)
+				continue
+			}
+
+			if len(types) > 0 && !containsString(types, event.Type) {
+				continue
+			}
+
+			if err := writeStreamEvent(w, event); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := w.WriteString(": heartbeat\n\n"); err != nil {
+				return
+			}
+
+			if err := w.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
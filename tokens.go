@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2/utils"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+	"text/tabwriter"
+)
+
+const (
+	tokenOk          = "ok"
+	tokenMissing     = "missing"
+	tokenInvalid     = "invalid"
+	tokenNotRequired = "not_required"
+	tokenUnreachable = "unreachable"
+	tokenUnknown     = "unknown"
+)
+
+// checkServiceToken sends an authenticated GET request to the service's token check URL and reports whether the token
+// was accepted. The URL must be an endpoint which rejects bad tokens, so lists without one are reported as unknown. The
+// request bypasses the circuit breaker so that a list which is down is reported as unreachable rather than skipped.
+func checkServiceToken(service string) TokenCheckResult {
+	result := TokenCheckResult{Service: service}
+
+	serviceConfig := getServiceConfig(service)
+	if serviceConfig.AuthPlacement == "none" {
+		result.Status = tokenNotRequired
+		return result
+	}
+
+	if getServiceToken(service) == "" {
+		result.Status = tokenMissing
+		result.Message = fmt.Sprintf("Neither SERVICES_%[1]s_TOKEN nor SERVICES_%[1]s_TOKEN_FILE is set.", utils.ToUpper(service))
+		return result
+	}
+
+	httpClient, clientErr := getServiceClient(service)
+	if clientErr != nil {
+		result.Status = tokenUnknown
+		result.Message = redactSecrets(clientErr.Error())
+		return result
+	}
+
+	checkUrl := config.GetDefault(fmt.Sprintf("services.%s.token_check_url", service), "").(string)
+	if checkUrl == "" {
+		result.Status = tokenUnknown
+		result.Message = fmt.Sprintf("The token can't be checked as services.%s.token_check_url isn't set.", service)
+		return result
+	}
+
+	req, err := http.NewRequest("GET", checkUrl, nil)
+	if err != nil {
+		result.Status = tokenUnknown
+		result.Message = redactSecrets(err.Error())
+		return result
+	}
+
+	applyServiceAuth(req, serviceConfig)
+
+	resp, respErr := httpClient.Do(req)
+	if respErr != nil {
+		result.Status = tokenUnreachable
+		result.Message = redactSecrets(respErr.Error())
+		return result
+	}
+
+	defer resp.Body.Close()
+
+	result.StatusCode = resp.StatusCode
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		result.Status = tokenInvalid
+		result.Message = fmt.Sprintf("The bot list rejected the token with status %d, it is invalid or has expired.", resp.StatusCode)
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		result.Status = tokenOk
+	default:
+		result.Status = tokenUnknown
+		result.Message = fmt.Sprintf("The bot list responded with status %d.", resp.StatusCode)
+	}
+
+	return result
+}
+
+// checkServiceTokens checks the token of every active service concurrently, sorted by service.
+func checkServiceTokens() []TokenCheckResult {
+	wg := sync.WaitGroup{}
+	locker := sync.Mutex{}
+
+	results := make([]TokenCheckResult, 0)
+	for _, service := range getActiveServices() {
+		wg.Add(1)
+		go func(s string) {
+			defer wg.Done()
+
+			result := checkServiceToken(s)
+
+			locker.Lock()
+			defer locker.Unlock()
+
+			results = append(results, result)
+		}(service)
+	}
+
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Service < results[j].Service })
+
+	return results
+}
+
+// checkTokensOnStartup logs a warning for every token that isn't known to work, so that bad tokens are noticed before
+// the next post fails.
+func checkTokensOnStartup() {
+	if !config.GetDefault("tokens.check_on_startup", false).(bool) {
+		return
+	}
+
+	go func() {
+		for _, result := range checkServiceTokens() {
+			if result.Status == tokenOk || result.Status == tokenNotRequired {
+				continue
+			}
+
+			log.Printf("Warning: the token for %s is %s. %s", result.Service, result.Status, result.Message)
+		}
+	}()
+}
+
+// runTokensCheck checks every token and prints the results, returning a non-zero exit code if any token is missing
+// or invalid.
+func runTokensCheck() int {
+	results := checkServiceTokens()
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "SERVICE\tSTATUS\tMESSAGE")
+	code := 0
+	for _, result := range results {
+		fmt.Fprintf(writer, "%s\t%s\t%s\n", result.Service, result.Status, result.Message)
+		if result.Status == tokenMissing || result.Status == tokenInvalid {
+			code = 1
+		}
+	}
+
+	writer.Flush()
+
+	return code
+}
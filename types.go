@@ -1,39 +1,496 @@
 package main
 
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
 type GuildCountResponse struct {
-	Guilds    int64 `json:"guild_count" example:"50000"`
-	Shards    int64 `json:"shard_count" example:"50"`
-	Timestamp int64 `json:"timestamp" example:"1671940391185"`
-	DryRun    bool  `json:"dry_run" example:"false"`
+	Guilds     int64                   `json:"guild_count" example:"50000"`
+	Shards     int64                   `json:"shard_count" example:"50"`
+	ShardStats []ShardGuildCount       `json:"shard_stats,omitempty"`
+	Stats      map[string]int64        `json:"stats,omitempty"`
+	Services   []string                `json:"services,omitempty" example:"topgg"`
+	Previews   []ServiceRequestPreview `json:"previews,omitempty"`
+	Timestamp  int64                   `json:"timestamp" example:"1671940391185"`
+	DryRun     bool                    `json:"dry_run" example:"false"`
+}
+
+type ServiceRequestPreview struct {
+	Service  string            `json:"service" example:"topgg"`
+	Method   string            `json:"method,omitempty" example:"POST"`
+	Url      string            `json:"url,omitempty" example:"https://top.gg/api/bots/474051954998509571/stats"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	Body     json.RawMessage   `json:"body,omitempty" swaggertype:"object"`
+	Valid    bool              `json:"valid" example:"true"`
+	Errors   []string          `json:"errors,omitempty"`
+	Warnings []string          `json:"warnings,omitempty"`
 }
 
 type GuildCountRequestBody struct {
-	Guilds int64 `json:"guild_count" validate:"required,number" example:"50000"`
-	Shards int64 `json:"shard_count" validate:"required,number" example:"50"`
-	DryRun bool  `json:"dry_run" validate:"boolean" example:"true"`
+	Guilds     int64             `json:"guild_count" validate:"required,number" example:"50000"`
+	Shards     int64             `json:"shard_count" validate:"required,number,max=10000" example:"50"`
+	ShardStats []ShardGuildCount `json:"shard_stats" validate:"omitempty,max=10000,unique=ShardId,dive"`
+	Stats      map[string]int64  `json:"stats" validate:"omitempty,dive,keys,required,ne=guild_count,ne=shard_count,ne=shards,ne=shard_id,endkeys,min=0"`
+	Services   *ServiceSelection `json:"services" validate:"omitempty"`
+	DryRun     bool              `json:"dry_run" validate:"boolean" example:"true"`
+}
+
+type ServiceSelection struct {
+	Include []string `json:"include" validate:"omitempty,dive,required" example:"primary"`
+	Exclude []string `json:"exclude" validate:"omitempty,dive,required" example:"discords"`
+}
+
+type ShardGuildCount struct {
+	ShardId int64 `json:"shard_id" validate:"min=0" example:"0"`
+	Guilds  int64 `json:"guild_count" validate:"min=0" example:"1000"`
 }
 
 type BotListServiceResponse struct {
-	ShortName  string `json:"short_name" example:"topgg"`
-	Url        string `json:"url" example:"https://top.gg"`
-	GuildCount int64  `json:"guild_count" example:"50000"`
-	Error      bool   `json:"error" validate:"omitempty" example:"false"`
+	ShortName        string             `json:"short_name" example:"topgg"`
+	Url              string             `json:"url" example:"https://top.gg"`
+	GuildCount       int64              `json:"guild_count" example:"50000"`
+	ShardCount       *int64             `json:"shard_count,omitempty" example:"50"`
+	Votes            *int64             `json:"votes,omitempty" example:"12000"`
+	MonthlyVotes     *int64             `json:"monthly_votes,omitempty" example:"800"`
+	Rank             *int64             `json:"rank,omitempty" example:"120"`
+	Rating           *float64           `json:"rating,omitempty" example:"4.8"`
+	Error            bool               `json:"error" validate:"omitempty" example:"false"`
+	ExtractionErrors []*ExtractionError `json:"extraction_errors,omitempty"`
+	Breaker          *BreakerStatus     `json:"breaker,omitempty"`
+}
+
+type BreakerStatus struct {
+	State    string `json:"state" example:"open"`
+	Failures int    `json:"failures" example:"5"`
+	OpenedAt int64  `json:"opened_at,omitempty" example:"1671940391185"`
+	RetryAt  int64  `json:"retry_at,omitempty" example:"1671940451185"`
 }
 
 type BotListServicesResponse struct {
 	Services    []BotListServiceResponse `json:"services"`
 	LastUpdated int64                    `json:"last_updated" example:"1671940391185"`
+	CacheAge    int64                    `json:"cache_age" example:"12000"`
+}
+
+type ListHistoryQuery struct {
+	From  int64 `query:"from" validate:"min=0" example:"1671940391185"`
+	To    int64 `query:"to" validate:"min=0" example:"1671940391185"`
+	Limit int64 `query:"limit" validate:"min=1,max=1000" example:"500"`
+}
+
+type ListSnapshot struct {
+	GuildCount   int64    `json:"guild_count" example:"50000"`
+	ShardCount   *int64   `json:"shard_count,omitempty" example:"50"`
+	Votes        *int64   `json:"votes,omitempty" example:"12000"`
+	MonthlyVotes *int64   `json:"monthly_votes,omitempty" example:"800"`
+	Rank         *int64   `json:"rank,omitempty" example:"120"`
+	Rating       *float64 `json:"rating,omitempty" example:"4.8"`
+	Timestamp    int64    `json:"timestamp" example:"1671940391185"`
+}
+
+type ListHistoryResponse struct {
+	Service   string         `json:"service" example:"topgg"`
+	From      int64          `json:"from" example:"1669348391185"`
+	To        int64          `json:"to" example:"1671940391185"`
+	Snapshots []ListSnapshot `json:"snapshots"`
+}
+
+type PublicGuildCountResponse struct {
+	GuildCount int64 `json:"guild_count" example:"50000"`
+	Timestamp  int64 `json:"timestamp" example:"1671940391185"`
+}
+
+type PublicServiceResponse struct {
+	ShortName  string `json:"short_name" example:"topgg"`
+	LongName   string `json:"long_name" example:"Top.gg"`
+	Url        string `json:"url" example:"https://top.gg"`
+	VoteUrl    string `json:"vote_url,omitempty" example:"https://top.gg/bot/474051954998509571/vote"`
+	GuildCount int64  `json:"guild_count" example:"50000"`
+}
+
+type BadgeQuery struct {
+	Label  string `query:"label" validate:"max=32" example:"servers"`
+	Color  string `query:"color" validate:"omitempty" example:"blurple"`
+	Format string `query:"format" validate:"oneof=compact full" example:"compact"`
+}
+
+type VoteBadgeQuery struct {
+	Label  string `query:"label" validate:"max=32" example:"votes"`
+	Color  string `query:"color" validate:"omitempty" example:"blurple"`
+	Format string `query:"format" validate:"oneof=compact full" example:"compact"`
+	Period string `query:"period" validate:"oneof=day week month all" example:"month"`
+}
+
+type SparklineQuery struct {
+	Days   int    `query:"days" validate:"min=2,max=90" example:"30"`
+	Width  int    `query:"width" validate:"min=20,max=1000" example:"120"`
+	Height int    `query:"height" validate:"min=10,max=200" example:"20"`
+	Color  string `query:"color" validate:"omitempty" example:"blurple"`
 }
 
 type BotListServiceConfig struct {
-	ShortName    string
-	LongName     string
-	Url          string
-	GetStatsUrl  string
-	PostStatsUrl string
-	Accessor     string
-	Key          string
-	Enabled      bool
+	ShortName     string
+	LongName      string
+	Url           string
+	GetStatsUrl   string
+	PostStatsUrl  string
+	Extract       map[string]string
+	Fields        map[string]string
+	Enabled       bool
+	Method        string
+	Encoding      string
+	AuthPlacement string
+	AuthName      string
+	AuthPrefix    string
+	ShardPosting  string
+}
+
+type VoteConfig struct {
+	Enabled           bool
+	Cooldown          time.Duration
+	UserAccessor      string
+	WeekendAccessor   string
+	WeekendMultiplier float64
+	Url               string
+}
+
+type Vote struct {
+	Id        int64  `json:"id" example:"1"`
+	Service   string `json:"service" example:"topgg"`
+	UserId    string `json:"user_id" example:"158063324699951104"`
+	IsWeekend bool   `json:"is_weekend" example:"false"`
+	Timestamp int64  `json:"timestamp" example:"1671940391185"`
+}
+
+type VoteQuery struct {
+	Service string `query:"service" validate:"omitempty" example:"topgg"`
+	User    string `query:"user" validate:"omitempty" example:"158063324699951104"`
+	From    int64  `query:"from" validate:"min=0" example:"1671940391185"`
+	To      int64  `query:"to" validate:"min=0" example:"1671940391185"`
+	Page    int64  `query:"page" validate:"min=1" example:"1"`
+	Limit   int64  `query:"limit" validate:"min=1,max=100" example:"50"`
+}
+
+type VotesResponse struct {
+	Votes []Vote `json:"votes"`
+	Page  int64  `json:"page" example:"1"`
+	Limit int64  `json:"limit" example:"50"`
+	Total int64  `json:"total" example:"1200"`
+}
+
+type ServiceVoteStatus struct {
+	Service     string `json:"service" example:"topgg"`
+	Voted       bool   `json:"voted" example:"true"`
+	CanVote     bool   `json:"can_vote" example:"false"`
+	LastVotedAt int64  `json:"last_voted_at,omitempty" example:"1671940391185"`
+	NextVoteAt  int64  `json:"next_vote_at,omitempty" example:"1671983591185"`
+	Cooldown    int64  `json:"cooldown" example:"43200000"`
+}
+
+type UserVoteStatusResponse struct {
+	UserId   string              `json:"user_id" example:"158063324699951104"`
+	HasVoted bool                `json:"has_voted" example:"true"`
+	Services []ServiceVoteStatus `json:"services"`
+}
+
+type LeaderboardQuery struct {
+	Period  string `query:"period" validate:"oneof=day week month all" example:"month"`
+	Service string `query:"service" validate:"omitempty" example:"topgg"`
+	From    int64  `query:"from" validate:"min=0" example:"1671940391185"`
+	To      int64  `query:"to" validate:"min=0" example:"1671940391185"`
+	Limit   int64  `query:"limit" validate:"min=1,max=100" example:"10"`
+}
+
+type LeaderboardEntry struct {
+	Rank   int64   `json:"rank" example:"1"`
+	UserId string  `json:"user_id" example:"158063324699951104"`
+	Votes  int64   `json:"votes" example:"40"`
+	Points float64 `json:"points" example:"52"`
+}
+
+type LeaderboardResponse struct {
+	Period  string             `json:"period" example:"month"`
+	Service string             `json:"service,omitempty" example:"topgg"`
+	From    int64              `json:"from" example:"1669852800000"`
+	To      int64              `json:"to" example:"1671940391185"`
+	Entries []LeaderboardEntry `json:"entries"`
+}
+
+type VoteStreak struct {
+	Current     int64 `json:"current" example:"5"`
+	Longest     int64 `json:"longest" example:"12"`
+	LastVotedAt int64 `json:"last_voted_at,omitempty" example:"1671940391185"`
+}
+
+type ServiceVoteStreak struct {
+	Service string `json:"service" example:"topgg"`
+	VoteStreak
+}
+
+type UserVoteStreakResponse struct {
+	UserId   string              `json:"user_id" example:"158063324699951104"`
+	Daily    VoteStreak          `json:"daily"`
+	Services []ServiceVoteStreak `json:"services"`
+}
+
+type ReminderSubscriptionRequestBody struct {
+	UserId   string   `json:"user_id" validate:"required,numeric,max=32" example:"158063324699951104"`
+	Services []string `json:"services" validate:"omitempty,dive,required" example:"topgg"`
+}
+
+type ReminderSubscription struct {
+	UserId    string         `json:"user_id" example:"158063324699951104"`
+	Services  []string       `json:"services" example:"topgg"`
+	Pending   []VoteReminder `json:"pending"`
+	Timestamp int64          `json:"timestamp" example:"1671940391185"`
+}
+
+type VoteReminder struct {
+	Id       int64  `json:"id" example:"1"`
+	UserId   string `json:"user_id" example:"158063324699951104"`
+	Service  string `json:"service" example:"topgg"`
+	RemindAt int64  `json:"remind_at" example:"1671983591185"`
+}
+
+type VoteReminderEvent struct {
+	UserId   string `json:"user_id" example:"158063324699951104"`
+	Service  string `json:"service" example:"topgg"`
+	VoteUrl  string `json:"vote_url" example:"https://top.gg/bot/474051954998509571/vote"`
+	RemindAt int64  `json:"remind_at" example:"1671983591185"`
+}
+
+type BotListError struct {
+	Service    string `json:"service" example:"topgg"`
+	StatusCode int    `json:"status_code,omitempty" example:"401"`
+	Message    string `json:"message" example:"The bot list responded with status 401."`
+}
+
+func (e *BotListError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Service, e.Message)
+}
+
+type ExtractionError struct {
+	Service string `json:"service" example:"topgg"`
+	Field   string `json:"field" example:"guild_count"`
+	Path    string `json:"path" example:"$.server_count"`
+	Message string `json:"message" example:"Couldn't extract 'guild_count' at '$.server_count': expected a number but found null."`
+}
+
+func (e *ExtractionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Service, e.Message)
+}
+
+type ServiceOverride struct {
+	Service     string `json:"service" example:"discords"`
+	Enabled     *bool  `json:"enabled,omitempty" example:"false"`
+	PausedUntil int64  `json:"paused_until,omitempty" example:"1671940391185"`
+	Reason      string `json:"reason,omitempty" example:"Discords.com is down."`
+	UpdatedAt   int64  `json:"updated_at" example:"1671940391185"`
+}
+
+type ServiceOverrideRequestBody struct {
+	Reason string `json:"reason" validate:"max=256" example:"Discords.com is down."`
+}
+
+type ServicePauseRequestBody struct {
+	Until    int64  `json:"until" validate:"required_without=Duration,excluded_with=Duration,min=0" example:"1671940391185"`
+	Duration string `json:"duration" validate:"required_without=Until" example:"2h"`
+	Reason   string `json:"reason" validate:"max=256" example:"Discords.com is down."`
+}
+
+type AdminServiceStatus struct {
+	Service       string           `json:"service" example:"discords"`
+	ConfigEnabled bool             `json:"config_enabled" example:"true"`
+	Active        bool             `json:"active" example:"false"`
+	Override      *ServiceOverride `json:"override,omitempty"`
+}
+
+type OverrideAuditQuery struct {
+	Limit int64 `query:"limit" validate:"min=1,max=100" example:"50"`
+}
+
+type ServiceOverrideAuditEntry struct {
+	Id          int64  `json:"id" example:"1"`
+	Service     string `json:"service" example:"discords"`
+	Action      string `json:"action" example:"paused" enums:"enabled,disabled,paused,reset"`
+	Enabled     *bool  `json:"enabled,omitempty" example:"true"`
+	PausedUntil int64  `json:"paused_until,omitempty" example:"1671940391185"`
+	Reason      string `json:"reason,omitempty" example:"Discords.com is down."`
+	Actor       string `json:"actor" example:"dashboard"`
+	Timestamp   int64  `json:"timestamp" example:"1671940391185"`
+}
+
+type AuditQuery struct {
+	Key    string `query:"key" validate:"omitempty" example:"dashboard"`
+	Method string `query:"method" validate:"omitempty,oneof=POST PUT PATCH DELETE post put patch delete" example:"POST"`
+	Route  string `query:"route" validate:"omitempty" example:"/api/v1/guilds"`
+	Status int    `query:"status" validate:"omitempty,min=100,max=599" example:"200"`
+	From   int64  `query:"from" validate:"min=0" example:"1671940391185"`
+	To     int64  `query:"to" validate:"min=0" example:"1671940391185"`
+	Page   int64  `query:"page" validate:"min=1" example:"1"`
+	Limit  int64  `query:"limit" validate:"min=1,max=100" example:"50"`
+}
+
+type AuditEntry struct {
+	Id         int64  `json:"id" example:"1"`
+	KeyName    string `json:"key_name" example:"dashboard"`
+	Ip         string `json:"ip" example:"127.0.0.1"`
+	Method     string `json:"method" example:"POST"`
+	Route      string `json:"route" example:"/api/v1/guilds"`
+	Path       string `json:"path" example:"/api/v1/guilds"`
+	Body       string `json:"body,omitempty" example:"{\"guild_count\":50000,\"shard_count\":50}"`
+	StatusCode int    `json:"status_code" example:"200"`
+	Error      string `json:"error,omitempty" example:"The service 'topgg' is not a valid service."`
+	Timestamp  int64  `json:"timestamp" example:"1671940391185"`
+}
+
+type AuditLogResponse struct {
+	Entries []AuditEntry `json:"entries"`
+	Page    int64        `json:"page" example:"1"`
+	Limit   int64        `json:"limit" example:"50"`
+	Total   int64        `json:"total" example:"1200"`
+}
+
+type TokenCheckResult struct {
+	Service    string `json:"service" example:"topgg"`
+	Status     string `json:"status" example:"invalid" enums:"ok,missing,invalid,not_required,unreachable,unknown"`
+	StatusCode int    `json:"status_code,omitempty" example:"401"`
+	Message    string `json:"message,omitempty" example:"The bot list rejected the token with status 401, it is invalid or has expired."`
+}
+
+type ServicePost struct {
+	Service string `json:"service" example:"topgg"`
+	Guilds  int64  `json:"guild_count" example:"50000"`
+	Shards  int64  `json:"shard_count" example:"50"`
+}
+
+type Event struct {
+	Id        int64           `json:"id" example:"1"`
+	Type      string          `json:"type" example:"vote.received"`
+	Data      json.RawMessage `json:"data" swaggertype:"object"`
+	Timestamp int64           `json:"timestamp" example:"1671940391185"`
+}
+
+type SubscriptionRequestBody struct {
+	Url    string   `json:"url" validate:"required,url" example:"https://suggestions.gg/api/webhooks/lists"`
+	Events []string `json:"events" validate:"omitempty,dive,required" example:"vote.received"`
+	Secret string   `json:"secret" validate:"omitempty,min=16" example:"a-secret-of-at-least-16-characters"`
+}
+
+type Subscription struct {
+	Id        int64    `json:"id" example:"1"`
+	Url       string   `json:"url" example:"https://suggestions.gg/api/webhooks/lists"`
+	Events    []string `json:"events" example:"vote.received"`
+	Enabled   bool     `json:"enabled" example:"true"`
+	Secret    string   `json:"secret,omitempty" example:"5f2b1c0e6f1d4c2a9b7e3d8c1a0f6e4b5f2b1c0e6f1d4c2a9b7e3d8c1a0f6e4b"`
+	Timestamp int64    `json:"timestamp" example:"1671940391185"`
+}
+
+type EventStreamQuery struct {
+	Events []string `query:"events" validate:"omitempty" example:"guilds.posted"`
+}
+
+type DeliveryQuery struct {
+	Status string `query:"status" validate:"omitempty,oneof=pending delivered failed" example:"failed"`
+	Page   int64  `query:"page" validate:"min=1" example:"1"`
+	Limit  int64  `query:"limit" validate:"min=1,max=100" example:"50"`
+}
+
+type EventDelivery struct {
+	Id             int64  `json:"id" example:"1"`
+	SubscriptionId int64  `json:"subscription_id" example:"1"`
+	EventId        int64  `json:"event_id" example:"1"`
+	Event          string `json:"event" example:"vote.received"`
+	Status         string `json:"status" example:"delivered"`
+	Attempts       int64  `json:"attempts" example:"1"`
+	ResponseCode   int64  `json:"response_code,omitempty" example:"200"`
+	Error          string `json:"error,omitempty" example:"The subscriber responded with status 500."`
+	DeliveredAt    int64  `json:"delivered_at,omitempty" example:"1671940391185"`
+	Timestamp      int64  `json:"timestamp" example:"1671940391185"`
+}
+
+type DeliveriesResponse struct {
+	Deliveries []EventDelivery `json:"deliveries"`
+	Page       int64           `json:"page" example:"1"`
+	Limit      int64           `json:"limit" example:"50"`
+	Total      int64           `json:"total" example:"120"`
+}
+
+type GuildCountPoint struct {
+	Guilds int64
+	Time   time.Time
+}
+
+type GrowthWindow struct {
+	Window     string  `json:"window" example:"7d"`
+	Start      int64   `json:"start" example:"49500"`
+	End        int64   `json:"end" example:"50000"`
+	NetChange  int64   `json:"net_change" example:"500"`
+	GrowthRate float64 `json:"growth_rate" example:"1.01"`
+}
+
+type DailyGrowth struct {
+	Date          string  `json:"date" example:"2022-12-25"`
+	Joins         int64   `json:"joins" example:"120"`
+	Leaves        int64   `json:"leaves" example:"45"`
+	NetChange     int64   `json:"net_change" example:"75"`
+	Close         int64   `json:"close" example:"50000"`
+	MovingAverage float64 `json:"moving_average" example:"49800.5"`
+}
+
+type GrowthProjection struct {
+	DailyGrowth   float64 `json:"daily_growth" example:"71.4"`
+	NextMilestone int64   `json:"next_milestone,omitempty" example:"75000"`
+	EstimatedAt   int64   `json:"estimated_at,omitempty" example:"1706940391185"`
+}
+
+type GuildStatsResponse struct {
+	GuildCount int64            `json:"guild_count" example:"50000"`
+	Windows    []GrowthWindow   `json:"windows"`
+	Daily      []DailyGrowth    `json:"daily"`
+	Projection GrowthProjection `json:"projection"`
+	Timestamp  int64            `json:"timestamp" example:"1671940391185"`
+}
+
+type Milestone struct {
+	Milestone          int64 `json:"milestone" example:"50000"`
+	GuildCount         int64 `json:"guild_count" example:"50012"`
+	PreviousGuildCount int64 `json:"previous_guild_count" example:"49987"`
+	Timestamp          int64 `json:"timestamp" example:"1671940391185"`
+}
+
+type MilestonesResponse struct {
+	Milestones []Milestone `json:"milestones"`
+	Next       int64       `json:"next,omitempty" example:"75000"`
+}
+
+type ServiceDrift struct {
+	Service           string `json:"service" example:"discords"`
+	GuildCount        int64  `json:"guild_count" example:"50000"`
+	DisplayedGuilds   int64  `json:"displayed_guild_count" example:"48000"`
+	Difference        int64  `json:"difference" example:"-2000"`
+	DifferenceAllowed int64  `json:"difference_allowed" example:"500"`
+}
+
+type DiscordWebhookBody struct {
+	Username string         `json:"username"`
+	Embeds   []DiscordEmbed `json:"embeds"`
+}
+
+type DiscordEmbed struct {
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Color       int                 `json:"color"`
+	Fields      []DiscordEmbedField `json:"fields,omitempty"`
+	Timestamp   string              `json:"timestamp"`
+}
+
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
 }
 
 type ErrorResponse struct {
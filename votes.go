@@ -0,0 +1,464 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/gofiber/fiber/v2/utils"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// getServiceVoteConfig returns the vote config of the service. Keys that aren't set, or are set to the wrong type, fall
+// back to their defaults, so an unknown service has votes disabled.
+func getServiceVoteConfig(service string) VoteConfig {
+	prefix := fmt.Sprintf("services.%s.votes", service)
+
+	enabled, _ := config.Get(prefix + ".enabled").(bool)
+	weekendAccessor, _ := config.Get(prefix + ".weekend_accessor").(string)
+
+	userAccessor, ok := config.Get(prefix + ".user_accessor").(string)
+	if !ok {
+		userAccessor = "user"
+	}
+
+	voteUrl, ok := config.Get(prefix + ".url").(string)
+	if !ok {
+		voteUrl, _ = config.Get(fmt.Sprintf("services.%s.url", service)).(string)
+	}
+
+	return VoteConfig{
+		Enabled:           enabled,
+		Cooldown:          getConfigDuration(prefix+".cooldown", time.Hour*12),
+		UserAccessor:      userAccessor,
+		WeekendAccessor:   weekendAccessor,
+		WeekendMultiplier: getConfigFloat(prefix+".weekend_multiplier", 1),
+		Url:               voteUrl,
+	}
+}
+
+// getVoteServices returns all active services that have vote webhooks enabled.
+func getVoteServices() []string {
+	var services []string
+	for _, service := range getActiveServices() {
+		if getServiceVoteConfig(service).Enabled {
+			services = append(services, service)
+		}
+	}
+
+	return services
+}
+
+func getServiceWebhookToken(service string) string {
+	return getSecret(fmt.Sprintf("SERVICES_%s_WEBHOOK_TOKEN", utils.ToUpper(service)))
+}
+
+// parseVoteWebhook pulls the voting user out of a vote webhook payload using the accessors set in the service's vote
+// config. The user must be a string or an integer, which is decoded as-is so that user ids aren't rounded.
+func parseVoteWebhook(service string, body []byte) (*Vote, error) {
+	voteConfig := getServiceVoteConfig(service)
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var data interface{}
+	if err := decoder.Decode(&data); err != nil {
+		return nil, err
+	}
+
+	user, userErr := lookupPath(data, voteConfig.UserAccessor)
+	if userErr != nil {
+		return nil, fmt.Errorf("The vote payload is missing the user at '%s': %s.", voteConfig.UserAccessor, userErr)
+	}
+
+	var userId string
+	switch value := user.(type) {
+	case string:
+		userId = value
+	case json.Number:
+		if _, parseErr := strconv.ParseUint(value.String(), 10, 64); parseErr != nil {
+			return nil, fmt.Errorf("The user at '%s' in the vote payload is not a valid id.", voteConfig.UserAccessor)
+		}
+
+		userId = value.String()
+	case nil:
+	default:
+		return nil, fmt.Errorf("The user at '%s' in the vote payload must be a string or an integer.", voteConfig.UserAccessor)
+	}
+
+	if userId == "" {
+		return nil, fmt.Errorf("The vote payload is missing the user at '%s'.", voteConfig.UserAccessor)
+	}
+
+	isWeekend := false
+	if voteConfig.WeekendAccessor != "" {
+		if weekend, err := lookupPath(data, voteConfig.WeekendAccessor); err == nil {
+			isWeekend, _ = weekend.(bool)
+		}
+	}
+
+	return &Vote{
+		Service:   service,
+		UserId:    userId,
+		IsWeekend: isWeekend,
+	}, nil
+}
+
+func insertVote(vote *Vote) error {
+	var createdAt time.Time
+
+	query := "insert into votes(service, user_id, is_weekend) values ($1, $2, $3) returning id, created_at"
+	err := conn.QueryRow(context.Background(), query, vote.Service, vote.UserId, vote.IsWeekend).Scan(&vote.Id, &createdAt)
+	if err != nil {
+		return err
+	}
+
+	vote.Timestamp = createdAt.UnixMilli()
+
+	return nil
+}
+
+// getVotes returns a page of votes matching the filter, most recent first, along with the total amount of matches.
+func getVotes(filter VoteQuery) ([]Vote, int64, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Service != "" {
+		addCondition("service = $%d", filter.Service)
+	}
+
+	if filter.User != "" {
+		addCondition("user_id = $%d", filter.User)
+	}
+
+	if filter.From > 0 {
+		addCondition("created_at >= $%d", time.UnixMilli(filter.From).UTC())
+	}
+
+	if filter.To > 0 {
+		addCondition("created_at < $%d", time.UnixMilli(filter.To).UTC())
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " where " + strings.Join(conditions, " and ")
+	}
+
+	var total int64
+	countErr := conn.QueryRow(context.Background(), "select count(*) from votes"+where, args...).Scan(&total)
+	if countErr != nil {
+		return nil, 0, countErr
+	}
+
+	query := fmt.Sprintf(
+		"select id, service, user_id, is_weekend, created_at from votes%s order by created_at desc limit $%d offset $%d",
+		where, len(args)+1, len(args)+2,
+	)
+	rows, err := queryRows(query, append(args, filter.Limit, (filter.Page-1)*filter.Limit)...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer rows.Close()
+
+	votes := make([]Vote, 0)
+	for rows.Next() {
+		var vote Vote
+		var createdAt time.Time
+		if scanErr := rows.Scan(&vote.Id, &vote.Service, &vote.UserId, &vote.IsWeekend, &createdAt); scanErr != nil {
+			return nil, 0, scanErr
+		}
+
+		vote.Timestamp = createdAt.UnixMilli()
+		votes = append(votes, vote)
+	}
+
+	return votes, total, rows.Err()
+}
+
+// getUserVoteStatus checks when the user last voted on every service accepting votes, and when they are able to vote
+// again based on each service's cooldown.
+func getUserVoteStatus(userId string) (*UserVoteStatusResponse, error) {
+	query := "select distinct on (service) service, created_at from votes where user_id = $1 order by service, created_at desc"
+	rows, err := queryRows(query, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	lastVotes := make(map[string]time.Time)
+	for rows.Next() {
+		var service string
+		var createdAt time.Time
+		if scanErr := rows.Scan(&service, &createdAt); scanErr != nil {
+			return nil, scanErr
+		}
+
+		lastVotes[service] = createdAt
+	}
+
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	now := time.Now()
+	status := &UserVoteStatusResponse{UserId: userId, Services: make([]ServiceVoteStatus, 0)}
+
+	for _, service := range getVoteServices() {
+		cooldown := getServiceVoteConfig(service).Cooldown
+		serviceStatus := ServiceVoteStatus{
+			Service:  service,
+			Cooldown: cooldown.Milliseconds(),
+			CanVote:  true,
+		}
+
+		if lastVote, ok := lastVotes[service]; ok {
+			nextVote := lastVote.Add(cooldown)
+
+			serviceStatus.LastVotedAt = lastVote.UnixMilli()
+			serviceStatus.NextVoteAt = nextVote.UnixMilli()
+			serviceStatus.Voted = now.Before(nextVote)
+			serviceStatus.CanVote = !serviceStatus.Voted
+		}
+
+		if serviceStatus.Voted {
+			status.HasVoted = true
+		}
+
+		status.Services = append(status.Services, serviceStatus)
+	}
+
+	return status, nil
+}
+
+// getLeaderboardRange returns the time range covered by the leaderboard period, with an explicit from or to in the
+// query taking precedence. Periods are aligned to UTC, weeks starting on Monday.
+func getLeaderboardRange(filter LeaderboardQuery, now time.Time) (time.Time, time.Time) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var from time.Time
+	switch filter.Period {
+	case "day":
+		from = today
+	case "week":
+		from = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	case "month":
+		from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		from = time.Unix(0, 0).UTC()
+	}
+
+	to := now
+	if filter.From > 0 {
+		from = time.UnixMilli(filter.From).UTC()
+	}
+
+	if filter.To > 0 {
+		to = time.UnixMilli(filter.To).UTC()
+	}
+
+	return from, to
+}
+
+// getLeaderboard ranks voters by points within the time range. Every vote is worth a point, weekend votes are worth
+// the weekend multiplier of the service they were cast on.
+func getLeaderboard(service string, from time.Time, to time.Time, limit int64) ([]LeaderboardEntry, error) {
+	var services []string
+	var multipliers []float64
+	for _, s := range getVoteServices() {
+		services = append(services, s)
+		multipliers = append(multipliers, getServiceVoteConfig(s).WeekendMultiplier)
+	}
+
+	args := []interface{}{services, multipliers, from, to, limit}
+	serviceCondition := ""
+	if service != "" {
+		args = append(args, service)
+		serviceCondition = " and v.service = $6"
+	}
+
+	query := `select v.user_id, count(*), sum(case when v.is_weekend then coalesce(m.multiplier, 1) else 1 end) as points
+		from votes v
+		left join unnest($1::text[], $2::float8[]) as m(service, multiplier) on m.service = v.service
+		where v.created_at >= $3 and v.created_at < $4` + serviceCondition + `
+		group by v.user_id
+		order by points desc, count(*) desc, min(v.created_at)
+		limit $5`
+
+	rows, err := queryRows(query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	entries := make([]LeaderboardEntry, 0)
+	for rows.Next() {
+		entry := LeaderboardEntry{Rank: int64(len(entries) + 1)}
+		if scanErr := rows.Scan(&entry.UserId, &entry.Votes, &entry.Points); scanErr != nil {
+			return nil, scanErr
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// getUserVoteStreak calculates the user's streak of consecutive UTC days with at least one vote on any list, as well
+// as their streak of consecutive cooldown windows voted on each list.
+func getUserVoteStreak(userId string) (*UserVoteStreakResponse, error) {
+	rows, err := queryRows("select service, created_at from votes where user_id = $1 order by created_at", userId)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	var allVotes []time.Time
+	serviceVotes := make(map[string][]time.Time)
+	for rows.Next() {
+		var service string
+		var createdAt time.Time
+		if scanErr := rows.Scan(&service, &createdAt); scanErr != nil {
+			return nil, scanErr
+		}
+
+		allVotes = append(allVotes, createdAt)
+		serviceVotes[service] = append(serviceVotes[service], createdAt)
+	}
+
+	if rowsErr := rows.Err(); rowsErr != nil {
+		return nil, rowsErr
+	}
+
+	now := time.Now()
+	streak := &UserVoteStreakResponse{
+		UserId:   userId,
+		Daily:    calculateDailyStreak(allVotes, now),
+		Services: make([]ServiceVoteStreak, 0),
+	}
+
+	for _, service := range getVoteServices() {
+		cooldown := getServiceVoteConfig(service).Cooldown
+		streak.Services = append(streak.Services, ServiceVoteStreak{
+			Service:    service,
+			VoteStreak: calculateWindowStreak(serviceVotes[service], cooldown, now),
+		})
+	}
+
+	return streak, nil
+}
+
+// calculateDailyStreak counts consecutive UTC days with a vote. The current streak is kept alive until a full day
+// passes without a vote.
+func calculateDailyStreak(votes []time.Time, now time.Time) VoteStreak {
+	var streak VoteStreak
+	if len(votes) == 0 {
+		return streak
+	}
+
+	day := func(t time.Time) time.Time {
+		t = t.UTC()
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	}
+
+	var run int64
+	var previous time.Time
+	for _, vote := range votes {
+		current := day(vote)
+		switch {
+		case run == 0 || current.After(previous.AddDate(0, 0, 1)):
+			run = 1
+		case current.Equal(previous.AddDate(0, 0, 1)):
+			run++
+		}
+
+		previous = current
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+	}
+
+	streak.LastVotedAt = votes[len(votes)-1].UnixMilli()
+	if !day(now).After(previous.AddDate(0, 0, 1)) {
+		streak.Current = run
+	}
+
+	return streak
+}
+
+// calculateWindowStreak counts consecutive votes on a list where each vote was cast before a whole cooldown window was
+// missed, meaning within twice the cooldown of the previous vote.
+func calculateWindowStreak(votes []time.Time, cooldown time.Duration, now time.Time) VoteStreak {
+	var streak VoteStreak
+	if len(votes) == 0 {
+		return streak
+	}
+
+	var run int64
+	for i, vote := range votes {
+		if i == 0 || vote.Sub(votes[i-1]) > cooldown*2 {
+			run = 1
+		} else {
+			run++
+		}
+
+		if run > streak.Longest {
+			streak.Longest = run
+		}
+	}
+
+	last := votes[len(votes)-1]
+	streak.LastVotedAt = last.UnixMilli()
+	if now.Sub(last) <= cooldown*2 {
+		streak.Current = run
+	}
+
+	return streak
+}
+
+// getListVoteCount returns the votes the list itself reports for the period, taken from the cached service data. The
+// votes are counted from those received through the vote webhook instead when the list doesn't report votes for the
+// period, which lists only do for the month and all time.
+func getListVoteCount(service string, period string, now time.Time) (int64, error) {
+	if period == "month" || period == "all" {
+		responses, _, _ := servicesCache.get(false)
+		for _, response := range responses {
+			if response.ShortName != service || response.Error {
+				continue
+			}
+
+			votes := response.Votes
+			if period == "month" {
+				votes = response.MonthlyVotes
+			}
+
+			if votes != nil {
+				return *votes, nil
+			}
+		}
+	}
+
+	from, to := getLeaderboardRange(LeaderboardQuery{Period: period}, now)
+
+	return countVotes(service, from, to)
+}
+
+// countVotes returns the amount of votes cast on the service within the time range.
+func countVotes(service string, from time.Time, to time.Time) (int64, error) {
+	var count int64
+	query := "select count(*) from votes where service = $1 and created_at >= $2 and created_at < $3"
+	err := conn.QueryRow(context.Background(), query, service, from, to).Scan(&count)
+
+	return count, err
+}